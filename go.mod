@@ -3,8 +3,10 @@ module github.com/Elias-Larsson/remdoc
 go 1.24.4
 
 require (
+	github.com/gorilla/websocket v1.5.3
 	github.com/spf13/cobra v1.10.2
 	golang.org/x/term v0.39.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -1,6 +1,15 @@
 package backend
 
-import "context"
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrNotFound is wrapped by errors for resources that don't exist on the
+// remote server, so callers can tell them apart with errors.Is
+var ErrNotFound = errors.New("not found")
 
 // Backend defines the interface for container management backends
 // (Portainer, custom Docker agent, etc.)
@@ -8,8 +17,8 @@ type Backend interface {
 	// Validate checks if the connection and credentials are valid
 	Validate(ctx context.Context) error
 	
-	// ListContainers returns all containers on the remote server
-	ListContainers(ctx context.Context) ([]Container, error)
+	// ListContainers returns the containers on the remote server matching opts
+	ListContainers(ctx context.Context, opts ListOptions) ([]Container, error)
 	
 	// DeployContainer creates and starts a new container
 	DeployContainer(ctx context.Context, opts DeployOptions) (*Container, error)
@@ -23,17 +32,125 @@ type Backend interface {
 	// StartContainer starts a stopped container
 	StartContainer(ctx context.Context, containerID string) error
 
-	// DeployComposeStack deploys a Docker Compose stack from content
-	DeployComposeStack(ctx context.Context, name string, composeContent string) (int, error)
+	// DeployComposeStack deploys a Docker Compose stack from content, with env
+	// available for ${VAR} interpolation
+	DeployComposeStack(ctx context.Context, name string, composeContent string, env []EnvVar) (int, error)
+
+	// ContainerLogs writes a container's logs to stdout and stderr
+	ContainerLogs(ctx context.Context, containerID string, opts LogsOptions, stdout, stderr io.Writer) error
+
+	// Exec runs a command inside a running container and returns its exit code
+	Exec(ctx context.Context, containerID string, opts ExecOptions) (int, error)
+
+	// InspectContainer returns detailed information about a container. The
+	// error wraps ErrNotFound if no such container exists.
+	InspectContainer(ctx context.Context, containerID string) (*ContainerDetails, error)
+
+	// ContainerStats reports resource usage samples for a container to
+	// onStats: a single sample, or a new one every second while stream is set
+	// until ctx is cancelled or the container stops
+	ContainerStats(ctx context.Context, containerID string, stream bool, onStats func(ContainerStats)) error
+
+	// Events reports Docker events to onEvent. Without opts.Until it keeps
+	// streaming until ctx is cancelled.
+	Events(ctx context.Context, opts EventsOptions, onEvent func(Event)) error
+
+	// ListVolumes returns the volumes matching the given Docker filters
+	ListVolumes(ctx context.Context, filters map[string][]string) ([]Volume, error)
+
+	// CreateVolume creates a named volume
+	CreateVolume(ctx context.Context, opts VolumeCreateOptions) (*Volume, error)
+
+	// InspectVolume returns a single volume by name. The error wraps
+	// ErrNotFound if no such volume exists.
+	InspectVolume(ctx context.Context, name string) (*Volume, error)
+
+	// RemoveVolume removes a volume by name
+	RemoveVolume(ctx context.Context, name string, force bool) error
+
+	// PruneVolumes removes unused volumes matching the given Docker filters
+	PruneVolumes(ctx context.Context, filters map[string][]string) (*PruneReport, error)
+
+	// ListNetworks returns the networks matching the given Docker filters
+	ListNetworks(ctx context.Context, filters map[string][]string) ([]Network, error)
+
+	// CreateNetwork creates a network
+	CreateNetwork(ctx context.Context, opts NetworkCreateOptions) (*Network, error)
+
+	// InspectNetwork returns a single network, including attached containers.
+	// The error wraps ErrNotFound if no such network exists.
+	InspectNetwork(ctx context.Context, network string) (*Network, error)
+
+	// RemoveNetwork removes a network by ID or name
+	RemoveNetwork(ctx context.Context, network string) error
+
+	// ConnectNetwork attaches a container to a network
+	ConnectNetwork(ctx context.Context, network, containerID string, opts NetworkEndpointOptions) error
+
+	// DisconnectNetwork detaches a container from a network
+	DisconnectNetwork(ctx context.Context, network, containerID string, force bool) error
+
+	// ListImages returns the images matching the given Docker filters
+	ListImages(ctx context.Context, all bool, filters map[string][]string) ([]Image, error)
+
+	// PullImage pulls an image, reporting progress messages to onProgress.
+	// auth may be nil for public images.
+	PullImage(ctx context.Context, image string, auth *RegistryAuth, onProgress func(PullProgress)) error
+
+	// InspectImage returns detailed information about an image. The error
+	// wraps ErrNotFound if the image isn't present on the remote host.
+	InspectImage(ctx context.Context, image string) (*ImageDetails, error)
+
+	// RemoveImage untags and removes an image by ID or reference
+	RemoveImage(ctx context.Context, image string, force bool) (*ImageRemoval, error)
+
+	// PruneImages removes unused images matching the given Docker filters
+	PruneImages(ctx context.Context, filters map[string][]string) (*PruneReport, error)
+
+	// ListStacks returns the stacks deployed to the selected environment
+	ListStacks(ctx context.Context) ([]Stack, error)
+
+	// InspectStack returns a stack by ID or name, including its compose file.
+	// The error wraps ErrNotFound if no such stack exists.
+	InspectStack(ctx context.Context, stack string) (*Stack, error)
+
+	// UpdateStack redeploys a stack by ID or name with new or unchanged content.
+	// Git-backed stacks are redeployed from their repository instead.
+	UpdateStack(ctx context.Context, stack string, opts StackUpdateOptions) error
+
+	// DeployGitStack deploys a Compose stack from a file in a Git repository
+	DeployGitStack(ctx context.Context, opts GitStackOptions) (int, error)
+
+	// PullStack redeploys a Git-backed stack from the latest commit of its
+	// reference
+	PullStack(ctx context.Context, stack string, opts StackPullOptions) error
+
+	// StopStack stops all services of a stack
+	StopStack(ctx context.Context, stack string) error
+
+	// StartStack starts a stopped stack
+	StartStack(ctx context.Context, stack string) error
+
+	// RemoveStack tears down a stack and removes it
+	RemoveStack(ctx context.Context, stack string) error
 }
 
 // Container represents a Docker container (simplified for now)
 type Container struct {
-	ID      string
-	Name    string
-	Image   string
-	State   string
-	Status  string
+	ID      string        `json:"id" yaml:"id"`
+	Name    string        `json:"name" yaml:"name"`
+	Image   string        `json:"image" yaml:"image"`
+	State   string        `json:"state" yaml:"state"`
+	Status  string        `json:"status" yaml:"status"`
+	Created time.Time     `json:"created,omitempty" yaml:"created,omitempty"`
+	Ports   []PortMapping `json:"ports,omitempty" yaml:"ports,omitempty"`
+}
+
+// ListOptions controls which containers ListContainers returns
+type ListOptions struct {
+	All     bool                // Include stopped containers
+	Limit   int                 // Return at most this many of the most recently created (0 = no limit)
+	Filters map[string][]string // Docker filters (e.g. "status": {"running"}, "label": {"app=web"})
 }
 
 // DeployOptions contains all parameters needed to deploy a container
@@ -44,11 +161,392 @@ type DeployOptions struct {
 	Env         map[string]string // Environment variables
 	Restart     string            // Restart policy (e.g., "unless-stopped")
 	AutoRemove  bool              // Remove container when stopped
+	Binds       []string          // Volume and bind mounts in docker -v form (e.g., "data:/data:ro")
+	Mounts      []Mount           // Mounts in docker --mount form
+	Network     string            // Network to attach to (empty = default bridge)
+	Aliases     []string          // DNS aliases on Network
+	IPAddress   string            // Static IPv4 address on Network (empty = assigned by IPAM)
+	Healthcheck *Healthcheck      // HEALTHCHECK override (nil = use the image's)
+	Hostname    string            // Container hostname (empty = short container ID)
+	User        string            // User (and group) to run as, e.g. "1000:1000" (empty = image's)
+	WorkingDir  string            // Working directory (empty = image's)
+	Entrypoint  []string          // Entrypoint override (nil = image's, [""] = clear it)
+	Cmd         []string          // Command and arguments (nil = image's)
+	Memory      int64             // Memory limit in bytes (0 = unlimited)
+	NanoCPUs    int64             // CPU quota in units of 1e-9 CPUs (0 = unlimited)
+	PidsLimit   int64             // Maximum number of processes (0 = daemon default, -1 = unlimited)
+	CapAdd      []string          // Kernel capabilities to add (e.g., "NET_ADMIN")
+	CapDrop     []string          // Kernel capabilities to drop (e.g., "ALL")
+	ReadOnly    bool              // Mount the root filesystem read-only
+	Privileged  bool              // Give the container extended privileges
+	SecurityOpt []string          // Security options (e.g., "no-new-privileges")
+	Ulimits     []Ulimit          // Resource limits (e.g., nofile)
+	LogDriver   string            // Logging driver (empty = daemon default)
+	LogOptions  map[string]string // Logging driver options
+}
+
+// Ulimit is a resource limit set on a new container
+type Ulimit struct {
+	Name string // e.g., "nofile" or "nproc"
+	Soft int64  // Soft limit (-1 = unlimited)
+	Hard int64  // Hard limit (-1 = unlimited)
+}
+
+// Healthcheck overrides the HEALTHCHECK of a new container. Zero durations
+// and retries keep the image's values.
+type Healthcheck struct {
+	Test        []string      // e.g. ["CMD-SHELL", "curl -f localhost"], ["NONE"] to disable, empty = image's
+	Interval    time.Duration // Time between checks
+	Timeout     time.Duration // Time a check may run before it counts as failed
+	StartPeriod time.Duration // Grace period during which failures don't count
+	Retries     int           // Consecutive failures before the container is unhealthy
+}
+
+// Mount describes a volume, bind or tmpfs mount for a new container
+type Mount struct {
+	Type            string            // "volume", "bind" or "tmpfs"
+	Source          string            // Volume name or host path (empty for tmpfs and anonymous volumes)
+	Target          string            // Path inside the container
+	ReadOnly        bool              // Mount read-only
+	BindPropagation string            // bind only: rprivate, private, rshared, shared, rslave or slave
+	VolumeNoCopy    bool              // volume only: don't populate a new volume from the image
+	VolumeDriver    string            // volume only: driver used to create the volume
+	VolumeOptions   map[string]string // volume only: driver options
+	VolumeLabels    map[string]string // volume only: labels for a newly created volume
+	TmpfsSize       int64             // tmpfs only: size in bytes (0 = unlimited)
+	TmpfsMode       uint32            // tmpfs only: file mode (e.g., 01777)
 }
 
 // PortMapping represents a port binding
 type PortMapping struct {
-	HostPort      string // Port on the host (e.g., "8080")
-	ContainerPort string // Port in the container (e.g., "80")
-	Protocol      string // "tcp" or "udp" (default: tcp)
-}
\ No newline at end of file
+	HostIP        string `json:"hostIp,omitempty" yaml:"hostIp,omitempty"`     // Host interface to bind (empty = all interfaces)
+	HostPort      string `json:"hostPort,omitempty" yaml:"hostPort,omitempty"` // Port or range on the host (e.g., "8080"; empty = random)
+	ContainerPort string `json:"containerPort" yaml:"containerPort"`           // Port in the container (e.g., "80")
+	Protocol      string `json:"protocol" yaml:"protocol"`                     // "tcp", "udp" or "sctp" (default: tcp)
+}
+
+// LogsOptions controls which container logs are returned
+type LogsOptions struct {
+	Follow     bool   // Keep streaming new output
+	Tail       string // Number of lines from the end ("all" for everything)
+	Since      string // Only logs after this Unix timestamp
+	Until      string // Only logs before this Unix timestamp
+	Timestamps bool   // Prefix each line with its timestamp
+}
+
+// ExecOptions contains the command and I/O streams for an exec session
+type ExecOptions struct {
+	Cmd         []string            // Command and arguments to run
+	TTY         bool                // Allocate a pseudo-TTY
+	Interactive bool                // Attach Stdin to the command
+	Stdin       io.Reader           // Input stream (used when Interactive)
+	Stdout      io.Writer           // Output stream
+	Stderr      io.Writer           // Error stream (merged into Stdout when TTY)
+	Resize      <-chan TerminalSize // Terminal size changes (used when TTY)
+}
+
+// TerminalSize represents the dimensions of a terminal
+type TerminalSize struct {
+	Width  uint
+	Height uint
+}
+
+// ContainerDetails is the detailed view of a container returned by InspectContainer
+type ContainerDetails struct {
+	ID            string                       `json:"id" yaml:"id"`
+	Name          string                       `json:"name" yaml:"name"`
+	Image         string                       `json:"image" yaml:"image"`
+	ImageID       string                       `json:"imageId" yaml:"imageId"`
+	Created       time.Time                    `json:"created" yaml:"created"`
+	State         ContainerState               `json:"state" yaml:"state"`
+	RestartCount  int                          `json:"restartCount" yaml:"restartCount"`
+	RestartPolicy string                       `json:"restartPolicy" yaml:"restartPolicy"`
+	Hostname      string                       `json:"hostname" yaml:"hostname"`
+	User          string                       `json:"user,omitempty" yaml:"user,omitempty"`
+	WorkingDir    string                       `json:"workingDir,omitempty" yaml:"workingDir,omitempty"`
+	Entrypoint    []string                     `json:"entrypoint,omitempty" yaml:"entrypoint,omitempty"`
+	Cmd           []string                     `json:"cmd,omitempty" yaml:"cmd,omitempty"`
+	Env           []string                     `json:"env,omitempty" yaml:"env,omitempty"`
+	Labels        map[string]string            `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Ports         []PortMapping                `json:"ports,omitempty" yaml:"ports,omitempty"`
+	Mounts        []MountPoint                 `json:"mounts,omitempty" yaml:"mounts,omitempty"`
+	Networks      map[string]NetworkAttachment `json:"networks,omitempty" yaml:"networks,omitempty"`
+}
+
+// ContainerState describes the runtime state of a container
+type ContainerState struct {
+	Status     string       `json:"status" yaml:"status"`
+	Running    bool         `json:"running" yaml:"running"`
+	Paused     bool         `json:"paused" yaml:"paused"`
+	Restarting bool         `json:"restarting" yaml:"restarting"`
+	OOMKilled  bool         `json:"oomKilled" yaml:"oomKilled"`
+	ExitCode   int          `json:"exitCode" yaml:"exitCode"`
+	Error      string       `json:"error,omitempty" yaml:"error,omitempty"`
+	StartedAt  time.Time    `json:"startedAt" yaml:"startedAt"`
+	FinishedAt time.Time    `json:"finishedAt" yaml:"finishedAt"`
+	Health     *HealthState `json:"health,omitempty" yaml:"health,omitempty"`
+}
+
+// HealthState is the result of a container's HEALTHCHECK
+type HealthState struct {
+	Status        string        `json:"status" yaml:"status"` // "starting", "healthy" or "unhealthy"
+	FailingStreak int           `json:"failingStreak" yaml:"failingStreak"`
+	Log           []HealthProbe `json:"log,omitempty" yaml:"log,omitempty"`
+}
+
+// HealthProbe is a single HEALTHCHECK run
+type HealthProbe struct {
+	Start    time.Time `json:"start" yaml:"start"`
+	End      time.Time `json:"end" yaml:"end"`
+	ExitCode int       `json:"exitCode" yaml:"exitCode"`
+	Output   string    `json:"output" yaml:"output"`
+}
+
+// MountPoint is a volume, bind or tmpfs mounted into a container
+type MountPoint struct {
+	Type        string `json:"type" yaml:"type"`
+	Name        string `json:"name,omitempty" yaml:"name,omitempty"`
+	Source      string `json:"source,omitempty" yaml:"source,omitempty"`
+	Destination string `json:"destination" yaml:"destination"`
+	Mode        string `json:"mode,omitempty" yaml:"mode,omitempty"`
+	RW          bool   `json:"rw" yaml:"rw"`
+}
+
+// NetworkAttachment describes a container's connection to a network
+type NetworkAttachment struct {
+	NetworkID  string   `json:"networkId" yaml:"networkId"`
+	IPAddress  string   `json:"ipAddress,omitempty" yaml:"ipAddress,omitempty"`
+	Gateway    string   `json:"gateway,omitempty" yaml:"gateway,omitempty"`
+	MacAddress string   `json:"macAddress,omitempty" yaml:"macAddress,omitempty"`
+	Aliases    []string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+}
+
+// Volume represents a Docker volume
+type Volume struct {
+	Name       string            `json:"name" yaml:"name"`
+	Driver     string            `json:"driver" yaml:"driver"`
+	Mountpoint string            `json:"mountpoint" yaml:"mountpoint"`
+	Scope      string            `json:"scope" yaml:"scope"`
+	CreatedAt  time.Time         `json:"createdAt" yaml:"createdAt"`
+	Labels     map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Options    map[string]string `json:"options,omitempty" yaml:"options,omitempty"`
+	UsedBy     []string          `json:"usedBy" yaml:"usedBy"` // Names of containers mounting the volume
+}
+
+// VolumeCreateOptions contains the parameters for creating a volume
+type VolumeCreateOptions struct {
+	Name       string            // Volume name (Docker generates one if empty)
+	Driver     string            // Volume driver (default: local)
+	DriverOpts map[string]string // Driver-specific options
+	Labels     map[string]string // Volume labels
+}
+
+// PruneReport summarizes the result of a prune operation
+type PruneReport struct {
+	Deleted        []string `json:"deleted" yaml:"deleted"`
+	Untagged       []string `json:"untagged,omitempty" yaml:"untagged,omitempty"` // Image references removed (images only)
+	SpaceReclaimed uint64   `json:"spaceReclaimed" yaml:"spaceReclaimed"`
+}
+
+// Network represents a Docker network
+type Network struct {
+	ID         string             `json:"id" yaml:"id"`
+	Name       string             `json:"name" yaml:"name"`
+	Driver     string             `json:"driver" yaml:"driver"`
+	Scope      string             `json:"scope" yaml:"scope"`
+	Internal   bool               `json:"internal" yaml:"internal"`
+	Attachable bool               `json:"attachable" yaml:"attachable"`
+	Created    time.Time          `json:"created" yaml:"created"`
+	Subnets    []NetworkSubnet    `json:"subnets,omitempty" yaml:"subnets,omitempty"`
+	Labels     map[string]string  `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Options    map[string]string  `json:"options,omitempty" yaml:"options,omitempty"`
+	Containers []NetworkContainer `json:"containers,omitempty" yaml:"containers,omitempty"` // Only populated by InspectNetwork
+}
+
+// NetworkSubnet is an IPAM address pool of a network
+type NetworkSubnet struct {
+	Subnet  string `json:"subnet" yaml:"subnet"`
+	Gateway string `json:"gateway,omitempty" yaml:"gateway,omitempty"`
+	IPRange string `json:"ipRange,omitempty" yaml:"ipRange,omitempty"`
+}
+
+// NetworkContainer is a container attached to a network
+type NetworkContainer struct {
+	ID          string `json:"id" yaml:"id"`
+	Name        string `json:"name" yaml:"name"`
+	IPv4Address string `json:"ipv4Address,omitempty" yaml:"ipv4Address,omitempty"`
+	IPv6Address string `json:"ipv6Address,omitempty" yaml:"ipv6Address,omitempty"`
+	MacAddress  string `json:"macAddress,omitempty" yaml:"macAddress,omitempty"`
+}
+
+// NetworkCreateOptions contains the parameters for creating a network
+type NetworkCreateOptions struct {
+	Name       string            // Network name
+	Driver     string            // Network driver (default: bridge)
+	Internal   bool              // Restrict external access to the network
+	Attachable bool              // Allow standalone containers to attach (overlay networks)
+	Subnet     string            // Subnet in CIDR form (e.g., "172.28.0.0/16")
+	Gateway    string            // Gateway for the subnet
+	IPRange    string            // Allocate container IPs from this sub-range
+	Labels     map[string]string // Network labels
+	Options    map[string]string // Driver-specific options
+}
+
+// NetworkEndpointOptions configures a container's connection to a network
+type NetworkEndpointOptions struct {
+	Aliases     []string // Extra DNS names for the container on the network
+	IPv4Address string   // Static IPv4 address (empty = assigned by IPAM)
+}
+
+// Image represents a Docker image
+type Image struct {
+	ID          string            `json:"id" yaml:"id"`
+	RepoTags    []string          `json:"repoTags" yaml:"repoTags"`
+	RepoDigests []string          `json:"repoDigests,omitempty" yaml:"repoDigests,omitempty"`
+	Created     time.Time         `json:"created" yaml:"created"`
+	Size        uint64            `json:"size" yaml:"size"`
+	Labels      map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+// ImageDetails is the detailed view of an image returned by InspectImage
+type ImageDetails struct {
+	Image        `yaml:",inline"`
+	Architecture string   `json:"architecture" yaml:"architecture"`
+	OS           string   `json:"os" yaml:"os"`
+	Author       string   `json:"author,omitempty" yaml:"author,omitempty"`
+	User         string   `json:"user,omitempty" yaml:"user,omitempty"`
+	WorkingDir   string   `json:"workingDir,omitempty" yaml:"workingDir,omitempty"`
+	Entrypoint   []string `json:"entrypoint,omitempty" yaml:"entrypoint,omitempty"`
+	Cmd          []string `json:"cmd,omitempty" yaml:"cmd,omitempty"`
+	Env          []string `json:"env,omitempty" yaml:"env,omitempty"`
+	ExposedPorts []string `json:"exposedPorts,omitempty" yaml:"exposedPorts,omitempty"`
+	Volumes      []string `json:"volumes,omitempty" yaml:"volumes,omitempty"`
+	Layers       []string `json:"layers,omitempty" yaml:"layers,omitempty"`
+}
+
+// PullProgress is a single progress message from an image pull
+type PullProgress struct {
+	ID      string // Layer ID, or the tag for the initial "Pulling from" message
+	Status  string // e.g. "Pulling fs layer", "Downloading", "Pull complete"
+	Current int64  // Bytes processed so far (Downloading/Extracting only)
+	Total   int64  // Total bytes (0 if unknown)
+}
+
+// RegistryAuth holds the credentials for pulling from a private registry.
+// Either RegistryID or the Docker credentials are set.
+type RegistryAuth struct {
+	RegistryID    int    // Registry configured in the backend (e.g. Portainer), which supplies the credentials
+	Username      string // Registry username
+	Password      string // Registry password or access token
+	IdentityToken string // OAuth identity token from a credential helper (used instead of Password)
+	ServerAddress string // Registry host (e.g. "ghcr.io" or "https://index.docker.io/v1/")
+}
+
+// ImageRemoval lists the references untagged and the layers deleted when
+// removing an image
+type ImageRemoval struct {
+	Untagged []string `json:"untagged" yaml:"untagged"`
+	Deleted  []string `json:"deleted" yaml:"deleted"`
+}
+
+// Stack represents a Compose stack managed by the backend
+type Stack struct {
+	ID         int        `json:"id" yaml:"id"`
+	Name       string     `json:"name" yaml:"name"`
+	Type       string     `json:"type" yaml:"type"`     // "compose", "swarm" or "kubernetes"
+	Status     string     `json:"status" yaml:"status"` // "active" or "inactive"
+	EndpointID int        `json:"endpointId" yaml:"endpointId"`
+	Created    time.Time  `json:"created" yaml:"created"`
+	CreatedBy  string     `json:"createdBy,omitempty" yaml:"createdBy,omitempty"`
+	Updated    time.Time  `json:"updated" yaml:"updated"`
+	UpdatedBy  string     `json:"updatedBy,omitempty" yaml:"updatedBy,omitempty"`
+	Env        []EnvVar   `json:"env,omitempty" yaml:"env,omitempty"`
+	Git        *GitSource `json:"git,omitempty" yaml:"git,omitempty"`         // Set for stacks deployed from Git
+	Content    string     `json:"content,omitempty" yaml:"content,omitempty"` // Compose file (InspectStack only)
+}
+
+// EnvVar is a stack environment variable
+type EnvVar struct {
+	Name  string `json:"name" yaml:"name"`
+	Value string `json:"value" yaml:"value"`
+}
+
+// StackUpdateOptions controls how UpdateStack redeploys a stack
+type StackUpdateOptions struct {
+	Content string   // New compose file (empty = keep the current one)
+	Env     []EnvVar // New environment (nil = keep the current one)
+	Prune   bool     // Remove services no longer in the compose file
+	Pull    bool     // Pull the latest images before redeploying
+}
+
+// GitSource describes where a Git-backed stack is deployed from
+type GitSource struct {
+	URL        string           `json:"url" yaml:"url"`
+	Ref        string           `json:"ref" yaml:"ref"`
+	Path       string           `json:"path" yaml:"path"`
+	Commit     string           `json:"commit,omitempty" yaml:"commit,omitempty"`
+	Username   string           `json:"username,omitempty" yaml:"username,omitempty"`
+	AutoUpdate *StackAutoUpdate `json:"autoUpdate,omitempty" yaml:"autoUpdate,omitempty"`
+}
+
+// StackAutoUpdate controls how the backend keeps a Git-backed stack up to date
+type StackAutoUpdate struct {
+	Interval string `json:"interval,omitempty" yaml:"interval,omitempty"` // Polling interval, e.g. "5m"
+	Webhook  string `json:"webhook,omitempty" yaml:"webhook,omitempty"`   // Webhook ID that triggers a redeploy
+}
+
+// GitStackOptions configures a stack deployed from a Git repository
+type GitStackOptions struct {
+	Name       string
+	URL        string
+	Ref        string // Branch or reference, e.g. "main" or "refs/tags/v1.2"
+	Path       string // Compose file path inside the repository
+	Username   string
+	Password   string
+	Env        []EnvVar
+	AutoUpdate *StackAutoUpdate
+}
+
+// StackPullOptions controls how PullStack redeploys a Git-backed stack
+type StackPullOptions struct {
+	Ref      string // New reference (empty = keep the current one)
+	Username string // New credentials (empty = keep the stored ones)
+	Password string
+	Env      []EnvVar // New environment (nil = keep the current one)
+	Prune    bool     // Remove services no longer in the compose file
+	Pull     bool     // Pull the latest images before redeploying
+}
+
+// ContainerStats is a resource usage sample for a container
+type ContainerStats struct {
+	ID            string    `json:"id" yaml:"id"`
+	Name          string    `json:"name" yaml:"name"`
+	Read          time.Time `json:"read" yaml:"read"`
+	CPUPercent    float64   `json:"cpuPercent" yaml:"cpuPercent"`
+	MemoryUsage   uint64    `json:"memoryUsage" yaml:"memoryUsage"` // Bytes, excluding page cache
+	MemoryLimit   uint64    `json:"memoryLimit" yaml:"memoryLimit"`
+	MemoryPercent float64   `json:"memoryPercent" yaml:"memoryPercent"`
+	NetworkRx     uint64    `json:"networkRx" yaml:"networkRx"`
+	NetworkTx     uint64    `json:"networkTx" yaml:"networkTx"`
+	BlockRead     uint64    `json:"blockRead" yaml:"blockRead"`
+	BlockWrite    uint64    `json:"blockWrite" yaml:"blockWrite"`
+	PIDs          uint64    `json:"pids" yaml:"pids"`
+}
+
+// EventsOptions controls which events Events returns
+type EventsOptions struct {
+	Since   string              // Only events after this Unix timestamp
+	Until   string              // Stop at this Unix timestamp (empty = stream)
+	Filters map[string][]string // Docker filters (e.g. "type": {"container"}, "event": {"die"})
+}
+
+// Event is something that happened on the Docker host, such as a container
+// dying or an image being pulled
+type Event struct {
+	Time       time.Time         `json:"time" yaml:"time"`
+	Type       string            `json:"type" yaml:"type"`     // "container", "image", "volume", "network", ...
+	Action     string            `json:"action" yaml:"action"` // "start", "die", "oom", "pull", "create", ...
+	ActorID    string            `json:"actorId" yaml:"actorId"`
+	Attributes map[string]string `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+	Scope      string            `json:"scope,omitempty" yaml:"scope,omitempty"`
+}
@@ -0,0 +1,140 @@
+package portainer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Endpoint represents a Portainer environment (a Docker host, agent, etc.)
+type Endpoint struct {
+	ID     int    `json:"Id"`
+	Name   string `json:"Name"`
+	Type   int    `json:"Type"`
+	URL    string `json:"URL"`
+	Status int    `json:"Status"`
+}
+
+// TypeName returns a human-readable name for the endpoint type
+func (e Endpoint) TypeName() string {
+	switch e.Type {
+	case 1:
+		return "docker"
+	case 2:
+		return "agent"
+	case 3:
+		return "azure"
+	case 4:
+		return "edge-agent"
+	case 5:
+		return "kubernetes"
+	case 6:
+		return "kubernetes-agent"
+	case 7:
+		return "kubernetes-edge-agent"
+	default:
+		return "unknown"
+	}
+}
+
+// IsDocker reports whether the endpoint runs Docker, directly or through an agent
+func (e Endpoint) IsDocker() bool {
+	return e.Type == 1 || e.Type == 2 || e.Type == 4
+}
+
+// StatusName returns a human-readable name for the endpoint status
+func (e Endpoint) StatusName() string {
+	switch e.Status {
+	case 1:
+		return "up"
+	case 2:
+		return "down"
+	default:
+		return "unknown"
+	}
+}
+
+// ListEndpoints returns all environments managed by the Portainer instance
+func (c *Client) ListEndpoints(ctx context.Context) ([]Endpoint, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+"/api/endpoints", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch endpoints: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	var endpoints []Endpoint
+	if err := json.NewDecoder(resp.Body).Decode(&endpoints); err != nil {
+		return nil, fmt.Errorf("failed to parse endpoints: %w", err)
+	}
+
+	return endpoints, nil
+}
+
+// FindEndpoint looks up an endpoint by ID or name; see SelectEndpoint
+func (c *Client) FindEndpoint(ctx context.Context, ref string) (*Endpoint, error) {
+	endpoints, err := c.ListEndpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return SelectEndpoint(endpoints, ref)
+}
+
+// SelectEndpoint picks an endpoint from a list by ID or name. An empty ref
+// selects the first Docker endpoint, since other types can't run containers.
+func SelectEndpoint(endpoints []Endpoint, ref string) (*Endpoint, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		for i := range endpoints {
+			if endpoints[i].IsDocker() {
+				return &endpoints[i], nil
+			}
+		}
+		return nil, fmt.Errorf("no Docker endpoints configured in Portainer")
+	}
+
+	// An exact ID match takes precedence over a name that happens to be numeric
+	if id, err := strconv.Atoi(ref); err == nil {
+		for i := range endpoints {
+			if endpoints[i].ID == id {
+				return &endpoints[i], nil
+			}
+		}
+	}
+
+	for i := range endpoints {
+		if endpoints[i].Name == ref {
+			return &endpoints[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("endpoint %q not found (run 'remdoc endpoints' to list available endpoints)", ref)
+}
+
+// resolveEndpoint returns the ID of the endpoint selected by c.Endpoint,
+// falling back to the first Docker endpoint when none is configured
+func (c *Client) resolveEndpoint(ctx context.Context) (int, error) {
+	if c.endpointID != 0 {
+		return c.endpointID, nil
+	}
+
+	endpoint, err := c.FindEndpoint(ctx, c.Endpoint)
+	if err != nil {
+		return 0, err
+	}
+
+	c.endpointID = endpoint.ID
+	return c.endpointID, nil
+}
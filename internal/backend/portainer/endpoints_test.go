@@ -0,0 +1,51 @@
+package portainer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSelectEndpoint(t *testing.T) {
+	endpoints := []Endpoint{
+		{ID: 1, Name: "k8s", Type: 5},
+		{ID: 2, Name: "production", Type: 2},
+		{ID: 3, Name: "1", Type: 1},
+		{ID: 4, Name: "staging", Type: 1},
+	}
+
+	tests := []struct {
+		name      string
+		endpoints []Endpoint
+		ref       string
+		wantID    int
+		wantErr   string
+	}{
+		{name: "default skips non-Docker endpoints", endpoints: endpoints, wantID: 2},
+		{name: "by ID", endpoints: endpoints, ref: "4", wantID: 4},
+		{name: "ID wins over numeric name", endpoints: endpoints, ref: "1", wantID: 1},
+		{name: "by name", endpoints: endpoints, ref: "staging", wantID: 4},
+		{name: "ref is trimmed", endpoints: endpoints, ref: " production ", wantID: 2},
+		{name: "numeric name without matching ID", endpoints: endpoints[2:], ref: "1", wantID: 3},
+		{name: "unknown", endpoints: endpoints, ref: "dev", wantErr: `endpoint "dev" not found`},
+		{name: "no Docker endpoints", endpoints: endpoints[:1], wantErr: "no Docker endpoints"},
+		{name: "no endpoints", wantErr: "no Docker endpoints"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SelectEndpoint(tt.endpoints, tt.ref)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("SelectEndpoint(%q) error = %v, want %q", tt.ref, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SelectEndpoint(%q) unexpected error: %v", tt.ref, err)
+			}
+			if got.ID != tt.wantID {
+				t.Errorf("SelectEndpoint(%q) = endpoint %d, want %d", tt.ref, got.ID, tt.wantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package portainer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+)
+
+// rawEvent is Docker's event representation
+type rawEvent struct {
+	Type   string `json:"Type"`
+	Action string `json:"Action"`
+	Actor  struct {
+		ID         string            `json:"ID"`
+		Attributes map[string]string `json:"Attributes"`
+	} `json:"Actor"`
+	Scope    string `json:"scope"`
+	TimeNano int64  `json:"timeNano"`
+}
+
+func (c *Client) Events(ctx context.Context, opts backend.EventsOptions, onEvent func(backend.Event)) error {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	query := url.Values{}
+	if opts.Since != "" {
+		query.Set("since", opts.Since)
+	}
+	if opts.Until != "" {
+		query.Set("until", opts.Until)
+	}
+	if len(opts.Filters) > 0 {
+		encoded, err := json.Marshal(opts.Filters)
+		if err != nil {
+			return fmt.Errorf("failed to encode filters: %w", err)
+		}
+		query.Set("filters", string(encoded))
+	}
+
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/events?%s", c.BaseURL, endpointID, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.doStream(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch events: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var raw rawEvent
+		if err := decoder.Decode(&raw); err != nil {
+			// The stream ends at --until; cancelling ctx is the normal way to stop following
+			if err == io.EOF || ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to parse event: %w", err)
+		}
+
+		onEvent(backend.Event{
+			Time:       time.Unix(0, raw.TimeNano),
+			Type:       raw.Type,
+			Action:     raw.Action,
+			ActorID:    raw.Actor.ID,
+			Attributes: raw.Actor.Attributes,
+			Scope:      raw.Scope,
+		})
+	}
+}
@@ -0,0 +1,391 @@
+package portainer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+	"github.com/gorilla/websocket"
+)
+
+// execInterrupted is the exit code reported when the local side interrupts
+// an exec that is still running, matching what shells report for Ctrl-C
+const execInterrupted = 130
+
+// stdinCloseGrace is how long output must stay quiet after local input ends
+// before the stream is closed to deliver EOF to a command that is still
+// running. Portainer's bridge can't half-close the Docker stream, so closing
+// it is the only way to end the remote stdin.
+const stdinCloseGrace = 2 * time.Second
+
+func (c *Client) Exec(ctx context.Context, containerID string, opts backend.ExecOptions) (int, error) {
+	if len(opts.Cmd) == 0 {
+		return 0, fmt.Errorf("command cannot be empty")
+	}
+
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	execID, err := c.createExec(ctx, endpointID, containerID, opts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create exec: %w", err)
+	}
+
+	conn, err := c.dialExec(ctx, endpointID, execID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to attach to exec: %w", err)
+	}
+	defer conn.Close()
+
+	// Closing the connection unblocks the stream pumps when ctx is cancelled
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	if opts.TTY && opts.Resize != nil {
+		go c.forwardResize(ctx, endpointID, execID, opts.Resize)
+	}
+
+	activity := make(chan struct{}, 1)
+	if opts.Interactive && opts.Stdin != nil {
+		inputDone := make(chan struct{})
+		go pumpStdin(conn, opts.Stdin, inputDone)
+		go c.closeAfterInput(ctx, conn, endpointID, execID, inputDone, activity, done)
+	}
+
+	if err := pumpOutput(conn, opts, activity); err != nil && ctx.Err() == nil {
+		return 0, fmt.Errorf("exec stream failed: %w", err)
+	}
+
+	if ctx.Err() != nil {
+		return c.interruptedExitCode(endpointID, execID), nil
+	}
+
+	return c.execExitCode(ctx, endpointID, execID)
+}
+
+func (c *Client) createExec(ctx context.Context, endpointID int, containerID string, opts backend.ExecOptions) (string, error) {
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/containers/%s/exec", c.BaseURL, endpointID, containerID)
+
+	payload := map[string]interface{}{
+		"AttachStdin":  opts.Interactive,
+		"AttachStdout": true,
+		"AttachStderr": true,
+		"Tty":          opts.TTY,
+		"Cmd":          opts.Cmd,
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusCreated, http.StatusOK); err != nil {
+		return "", err
+	}
+
+	var result struct {
+		ID string `json:"Id"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return result.ID, nil
+}
+
+// dialExec opens Portainer's websocket bridge to the exec instance, which
+// starts it and relays the hijacked Docker stream
+func (c *Client) dialExec(ctx context.Context, endpointID int, execID string) (*websocket.Conn, error) {
+	wsURL, err := url.Parse(c.BaseURL + "/api/websocket/exec")
+	if err != nil {
+		return nil, fmt.Errorf("invalid Portainer URL: %w", err)
+	}
+
+	switch wsURL.Scheme {
+	case "https":
+		wsURL.Scheme = "wss"
+	default:
+		wsURL.Scheme = "ws"
+	}
+
+	query := url.Values{}
+	query.Set("endpointId", fmt.Sprintf("%d", endpointID))
+	query.Set("id", execID)
+	wsURL.RawQuery = query.Encode()
+
+	header := http.Header{}
+	authReq := &http.Request{Header: header}
+	c.authorize(authReq)
+
+	dialer := *websocket.DefaultDialer
+	if transport, ok := c.HTTPClient.Transport.(*http.Transport); ok {
+		dialer.TLSClientConfig = transport.TLSClientConfig
+		dialer.Proxy = transport.Proxy
+	}
+
+	conn, resp, err := dialer.DialContext(ctx, wsURL.String(), header)
+	if err != nil {
+		if resp != nil {
+			defer resp.Body.Close()
+			if checkErr := checkResponse(resp, http.StatusSwitchingProtocols); checkErr != nil {
+				return nil, checkErr
+			}
+		}
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// pumpStdin forwards local input to the websocket until the input ends,
+// closing inputDone if it ended with EOF
+func pumpStdin(conn *websocket.Conn, stdin io.Reader, inputDone chan<- struct{}) {
+	buf := make([]byte, 4096)
+	for {
+		n, err := stdin.Read(buf)
+		if n > 0 {
+			if writeErr := conn.WriteMessage(websocket.BinaryMessage, buf[:n]); writeErr != nil {
+				return
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			close(inputDone)
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// closeAfterInput delivers EOF to the remote command once local input has
+// ended. The stream stays open while output keeps arriving or the command
+// has already finished, so no output is cut off; it is closed only after
+// stdinCloseGrace without output while the command is still running, i.e.
+// when it is most likely waiting for more input.
+func (c *Client) closeAfterInput(ctx context.Context, conn *websocket.Conn, endpointID int, execID string,
+	inputDone <-chan struct{}, activity <-chan struct{}, done <-chan struct{}) {
+	select {
+	case <-inputDone:
+	case <-done:
+		return
+	}
+
+	timer := time.NewTimer(stdinCloseGrace)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-activity:
+		case <-timer.C:
+			_, running, err := c.inspectExec(ctx, endpointID, execID)
+			if err == nil && running {
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+				return
+			}
+		}
+		timer.Reset(stdinCloseGrace)
+	}
+}
+
+// pumpOutput copies websocket output to the local streams until the remote
+// side closes the connection, signalling activity for each message received
+func pumpOutput(conn *websocket.Conn, opts backend.ExecOptions, activity chan<- struct{}) error {
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	go func() {
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+					err = nil
+				}
+				pw.CloseWithError(err)
+				return
+			}
+			select {
+			case activity <- struct{}{}:
+			default:
+			}
+			if _, err := pw.Write(data); err != nil {
+				return
+			}
+		}
+	}()
+
+	stdout, stderr := opts.Stdout, opts.Stderr
+	if stdout == nil {
+		stdout = io.Discard
+	}
+	if stderr == nil {
+		stderr = stdout
+	}
+
+	if opts.TTY {
+		_, err := io.Copy(stdout, pr)
+		return ignoreClosed(err)
+	}
+
+	// Without a TTY Docker multiplexes stdout and stderr; fall back to a raw
+	// copy if the stream turns out not to be framed
+	br := bufio.NewReader(pr)
+	if header, err := br.Peek(8); err == nil && isFrameHeader(header) {
+		return ignoreClosed(demuxStream(br, stdout, stderr))
+	}
+
+	_, err := io.Copy(stdout, br)
+	return ignoreClosed(err)
+}
+
+// isFrameHeader reports whether b looks like a multiplexed stream header
+func isFrameHeader(b []byte) bool {
+	return b[0] <= streamStderr && b[1] == 0 && b[2] == 0 && b[3] == 0
+}
+
+// ignoreClosed treats an abruptly closed websocket as the end of the stream
+func ignoreClosed(err error) error {
+	var closeErr *websocket.CloseError
+	if err == nil || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, net.ErrClosed) || errors.As(err, &closeErr) {
+		return nil
+	}
+	return err
+}
+
+// forwardResize applies terminal size changes to the exec instance
+func (c *Client) forwardResize(ctx context.Context, endpointID int, execID string, sizes <-chan backend.TerminalSize) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case size, ok := <-sizes:
+			if !ok {
+				return
+			}
+			if size.Width == 0 || size.Height == 0 {
+				continue
+			}
+
+			url := fmt.Sprintf("%s/api/endpoints/%d/docker/exec/%s/resize?h=%d&w=%d",
+				c.BaseURL, endpointID, execID, size.Height, size.Width)
+
+			req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
+			if err != nil {
+				continue
+			}
+
+			// Resizing is best effort; the session keeps working at the old size
+			if resp, err := c.do(req); err == nil {
+				resp.Body.Close()
+			}
+		}
+	}
+}
+
+// execExitCode waits for the exec instance to finish and returns its exit code
+func (c *Client) execExitCode(ctx context.Context, endpointID int, execID string) (int, error) {
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+
+	// The stream can close before the command exits, e.g. after stdin was
+	// closed, so poll until it stops or ctx is cancelled
+	for {
+		exitCode, running, err := c.inspectExec(ctx, endpointID, execID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return execInterrupted, nil
+			}
+			return 0, err
+		}
+
+		if !running {
+			return exitCode, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return execInterrupted, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// interruptedExitCode returns the exit code of an interrupted exec if the
+// command already finished, and execInterrupted otherwise
+func (c *Client) interruptedExitCode(endpointID int, execID string) int {
+	// The caller's context is already cancelled, so use a short one of our own
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	exitCode, running, err := c.inspectExec(ctx, endpointID, execID)
+	if err != nil || running {
+		return execInterrupted
+	}
+	return exitCode
+}
+
+// inspectExec reports whether an exec instance is still running and its exit code
+func (c *Client) inspectExec(ctx context.Context, endpointID int, execID string) (int, bool, error) {
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/exec/%s/json", c.BaseURL, endpointID, execID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to inspect exec: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return 0, false, err
+	}
+
+	var result struct {
+		Running  bool `json:"Running"`
+		ExitCode int  `json:"ExitCode"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, false, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return result.ExitCode, result.Running, nil
+}
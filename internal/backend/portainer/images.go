@@ -0,0 +1,400 @@
+package portainer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+)
+
+// rawImage is Docker's image list representation
+type rawImage struct {
+	ID          string            `json:"Id"`
+	RepoTags    []string          `json:"RepoTags"`
+	RepoDigests []string          `json:"RepoDigests"`
+	Created     int64             `json:"Created"`
+	Size        uint64            `json:"Size"`
+	Labels      map[string]string `json:"Labels"`
+}
+
+// rawImageDetails is the subset of Docker's image inspect response we use
+type rawImageDetails struct {
+	ID           string   `json:"Id"`
+	RepoTags     []string `json:"RepoTags"`
+	RepoDigests  []string `json:"RepoDigests"`
+	Created      string   `json:"Created"`
+	Size         uint64   `json:"Size"`
+	Architecture string   `json:"Architecture"`
+	Os           string   `json:"Os"`
+	Author       string   `json:"Author"`
+	Config       struct {
+		User         string              `json:"User"`
+		WorkingDir   string              `json:"WorkingDir"`
+		Entrypoint   []string            `json:"Entrypoint"`
+		Cmd          []string            `json:"Cmd"`
+		Env          []string            `json:"Env"`
+		ExposedPorts map[string]struct{} `json:"ExposedPorts"`
+		Volumes      map[string]struct{} `json:"Volumes"`
+		Labels       map[string]string   `json:"Labels"`
+	} `json:"Config"`
+	RootFS struct {
+		Layers []string `json:"Layers"`
+	} `json:"RootFS"`
+}
+
+// pullMessage is a single message in Docker's streaming pull response
+type pullMessage struct {
+	ID             string `json:"id"`
+	Status         string `json:"status"`
+	ProgressDetail struct {
+		Current int64 `json:"current"`
+		Total   int64 `json:"total"`
+	} `json:"progressDetail"`
+	Error       string `json:"error"`
+	ErrorDetail struct {
+		Message string `json:"message"`
+	} `json:"errorDetail"`
+}
+
+// imageDeleteItem is an entry in Docker's image delete and prune responses
+type imageDeleteItem struct {
+	Untagged string `json:"Untagged"`
+	Deleted  string `json:"Deleted"`
+}
+
+func (c *Client) ListImages(ctx context.Context, all bool, filters map[string][]string) ([]backend.Image, error) {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	query := url.Values{}
+	query.Set("all", fmt.Sprintf("%t", all))
+	if len(filters) > 0 {
+		encoded, err := json.Marshal(filters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode filters: %w", err)
+		}
+		query.Set("filters", string(encoded))
+	}
+
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/images/json?%s", c.BaseURL, endpointID, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch images: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	var raw []rawImage
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	images := make([]backend.Image, len(raw))
+	for i, img := range raw {
+		images[i] = backend.Image{
+			ID:          img.ID,
+			RepoTags:    danglingTags(img.RepoTags),
+			RepoDigests: img.RepoDigests,
+			Created:     time.Unix(img.Created, 0),
+			Size:        img.Size,
+			Labels:      img.Labels,
+		}
+	}
+
+	sort.Slice(images, func(i, j int) bool { return images[i].Created.After(images[j].Created) })
+	return images, nil
+}
+
+// danglingTags drops Docker's "<none>:<none>" placeholder for untagged images
+func danglingTags(tags []string) []string {
+	result := []string{}
+	for _, tag := range tags {
+		if tag != "<none>:<none>" {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+func (c *Client) PullImage(ctx context.Context, image string, auth *backend.RegistryAuth, onProgress func(backend.PullProgress)) error {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	// Without a tag Docker pulls every tag of the repository
+	name, tag := splitImageRef(image)
+
+	query := url.Values{}
+	query.Set("fromImage", name)
+	query.Set("tag", tag)
+
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/images/create?%s", c.BaseURL, endpointID, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if auth != nil {
+		header, err := registryAuthHeader(auth)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("X-Registry-Auth", header)
+	}
+
+	resp, err := c.doStream(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("image %s %w (check the name and registry access)", image, backend.ErrNotFound)
+	}
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return err
+	}
+
+	// Errors such as missing tags or auth failures arrive in the stream
+	// after a 200 response
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var msg pullMessage
+		if err := decoder.Decode(&msg); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("failed to read pull progress: %w", err)
+		}
+
+		if msg.Error != "" {
+			if msg.ErrorDetail.Message != "" {
+				return fmt.Errorf("%s", msg.ErrorDetail.Message)
+			}
+			return fmt.Errorf("%s", msg.Error)
+		}
+
+		if onProgress != nil {
+			onProgress(backend.PullProgress{
+				ID:      msg.ID,
+				Status:  msg.Status,
+				Current: msg.ProgressDetail.Current,
+				Total:   msg.ProgressDetail.Total,
+			})
+		}
+	}
+}
+
+// splitImageRef splits an image reference into the repository and the tag
+// or digest, defaulting to "latest"
+func splitImageRef(image string) (string, string) {
+	if name, digest, ok := strings.Cut(image, "@"); ok {
+		return name, digest
+	}
+
+	// A colon before the last slash belongs to a registry host:port
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i], image[i+1:]
+	}
+
+	return image, "latest"
+}
+
+func (c *Client) InspectImage(ctx context.Context, image string) (*backend.ImageDetails, error) {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	// Image references may contain slashes, which Docker's route accepts as-is
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/images/%s/json", c.BaseURL, endpointID, image)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("image %s %w", image, backend.ErrNotFound)
+	}
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	var raw rawImageDetails
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	details := &backend.ImageDetails{
+		Image: backend.Image{
+			ID:          raw.ID,
+			RepoTags:    danglingTags(raw.RepoTags),
+			RepoDigests: raw.RepoDigests,
+			Created:     parseDockerTime(raw.Created),
+			Size:        raw.Size,
+			Labels:      raw.Config.Labels,
+		},
+		Architecture: raw.Architecture,
+		OS:           raw.Os,
+		Author:       raw.Author,
+		User:         raw.Config.User,
+		WorkingDir:   raw.Config.WorkingDir,
+		Entrypoint:   raw.Config.Entrypoint,
+		Cmd:          raw.Config.Cmd,
+		Env:          raw.Config.Env,
+		ExposedPorts: sortedKeys(raw.Config.ExposedPorts),
+		Volumes:      sortedKeys(raw.Config.Volumes),
+		Layers:       raw.RootFS.Layers,
+	}
+
+	return details, nil
+}
+
+// sortedKeys returns the keys of a Docker set such as ExposedPorts in order
+func sortedKeys(set map[string]struct{}) []string {
+	if len(set) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (c *Client) RemoveImage(ctx context.Context, image string, force bool) (*backend.ImageRemoval, error) {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/images/%s?force=%t", c.BaseURL, endpointID, image, force)
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("image %s %w", image, backend.ErrNotFound)
+	case http.StatusConflict:
+		return nil, fmt.Errorf("image %s is in use by a container (use --force to remove it anyway)", image)
+	}
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	var items []imageDeleteItem
+	if err := json.NewDecoder(resp.Body).Decode(&items); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	removal := &backend.ImageRemoval{Untagged: []string{}, Deleted: []string{}}
+	for _, item := range items {
+		if item.Untagged != "" {
+			removal.Untagged = append(removal.Untagged, item.Untagged)
+		}
+		if item.Deleted != "" {
+			removal.Deleted = append(removal.Deleted, item.Deleted)
+		}
+	}
+
+	return removal, nil
+}
+
+func (c *Client) PruneImages(ctx context.Context, filters map[string][]string) (*backend.PruneReport, error) {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	query := url.Values{}
+	if len(filters) > 0 {
+		encoded, err := json.Marshal(filters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode filters: %w", err)
+		}
+		query.Set("filters", string(encoded))
+	}
+
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/images/prune?%s", c.BaseURL, endpointID, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Pruning many images can take longer than the default client timeout
+	resp, err := c.doStream(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		ImagesDeleted  []imageDeleteItem `json:"ImagesDeleted"`
+		SpaceReclaimed uint64            `json:"SpaceReclaimed"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	report := &backend.PruneReport{
+		Deleted:        []string{},
+		SpaceReclaimed: result.SpaceReclaimed,
+	}
+	for _, item := range result.ImagesDeleted {
+		if item.Untagged != "" {
+			report.Untagged = append(report.Untagged, item.Untagged)
+		}
+		if item.Deleted != "" {
+			report.Deleted = append(report.Deleted, item.Deleted)
+		}
+	}
+
+	return report, nil
+}
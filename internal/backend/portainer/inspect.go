@@ -0,0 +1,237 @@
+package portainer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+)
+
+// rawContainer is the subset of Docker's container inspect response we use
+type rawContainer struct {
+	ID      string `json:"Id"`
+	Name    string `json:"Name"`
+	Image   string `json:"Image"`
+	Created string `json:"Created"`
+	State   struct {
+		Status     string `json:"Status"`
+		Running    bool   `json:"Running"`
+		Paused     bool   `json:"Paused"`
+		Restarting bool   `json:"Restarting"`
+		OOMKilled  bool   `json:"OOMKilled"`
+		ExitCode   int    `json:"ExitCode"`
+		Error      string `json:"Error"`
+		StartedAt  string `json:"StartedAt"`
+		FinishedAt string `json:"FinishedAt"`
+		Health     *struct {
+			Status        string `json:"Status"`
+			FailingStreak int    `json:"FailingStreak"`
+			Log           []struct {
+				Start    string `json:"Start"`
+				End      string `json:"End"`
+				ExitCode int    `json:"ExitCode"`
+				Output   string `json:"Output"`
+			} `json:"Log"`
+		} `json:"Health"`
+	} `json:"State"`
+	RestartCount int `json:"RestartCount"`
+	Config       struct {
+		Hostname   string            `json:"Hostname"`
+		User       string            `json:"User"`
+		WorkingDir string            `json:"WorkingDir"`
+		Tty        bool              `json:"Tty"`
+		Image      string            `json:"Image"`
+		Entrypoint []string          `json:"Entrypoint"`
+		Cmd        []string          `json:"Cmd"`
+		Env        []string          `json:"Env"`
+		Labels     map[string]string `json:"Labels"`
+	} `json:"Config"`
+	HostConfig struct {
+		RestartPolicy struct {
+			Name string `json:"Name"`
+		} `json:"RestartPolicy"`
+	} `json:"HostConfig"`
+	Mounts []struct {
+		Type        string `json:"Type"`
+		Name        string `json:"Name"`
+		Source      string `json:"Source"`
+		Destination string `json:"Destination"`
+		Mode        string `json:"Mode"`
+		RW          bool   `json:"RW"`
+	} `json:"Mounts"`
+	NetworkSettings struct {
+		Ports map[string][]struct {
+			HostIP   string `json:"HostIp"`
+			HostPort string `json:"HostPort"`
+		} `json:"Ports"`
+		Networks map[string]struct {
+			NetworkID  string   `json:"NetworkID"`
+			IPAddress  string   `json:"IPAddress"`
+			Gateway    string   `json:"Gateway"`
+			MacAddress string   `json:"MacAddress"`
+			Aliases    []string `json:"Aliases"`
+		} `json:"Networks"`
+	} `json:"NetworkSettings"`
+}
+
+func (c *Client) InspectContainer(ctx context.Context, containerID string) (*backend.ContainerDetails, error) {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	raw, err := c.inspectContainer(ctx, endpointID, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &backend.ContainerDetails{
+		ID:            raw.ID,
+		Name:          strings.TrimPrefix(raw.Name, "/"),
+		Image:         raw.Config.Image,
+		ImageID:       raw.Image,
+		Created:       parseDockerTime(raw.Created),
+		RestartCount:  raw.RestartCount,
+		RestartPolicy: raw.HostConfig.RestartPolicy.Name,
+		Hostname:      raw.Config.Hostname,
+		User:          raw.Config.User,
+		WorkingDir:    raw.Config.WorkingDir,
+		Entrypoint:    raw.Config.Entrypoint,
+		Cmd:           raw.Config.Cmd,
+		Env:           raw.Config.Env,
+		Labels:        raw.Config.Labels,
+		State: backend.ContainerState{
+			Status:     raw.State.Status,
+			Running:    raw.State.Running,
+			Paused:     raw.State.Paused,
+			Restarting: raw.State.Restarting,
+			OOMKilled:  raw.State.OOMKilled,
+			ExitCode:   raw.State.ExitCode,
+			Error:      raw.State.Error,
+			StartedAt:  parseDockerTime(raw.State.StartedAt),
+			FinishedAt: parseDockerTime(raw.State.FinishedAt),
+		},
+	}
+
+	if h := raw.State.Health; h != nil {
+		health := &backend.HealthState{
+			Status:        h.Status,
+			FailingStreak: h.FailingStreak,
+		}
+		for _, probe := range h.Log {
+			health.Log = append(health.Log, backend.HealthProbe{
+				Start:    parseDockerTime(probe.Start),
+				End:      parseDockerTime(probe.End),
+				ExitCode: probe.ExitCode,
+				Output:   probe.Output,
+			})
+		}
+		details.State.Health = health
+	}
+
+	for _, m := range raw.Mounts {
+		details.Mounts = append(details.Mounts, backend.MountPoint{
+			Type:        m.Type,
+			Name:        m.Name,
+			Source:      m.Source,
+			Destination: m.Destination,
+			Mode:        m.Mode,
+			RW:          m.RW,
+		})
+	}
+
+	// Sort ports so output is stable between runs
+	portKeys := make([]string, 0, len(raw.NetworkSettings.Ports))
+	for key := range raw.NetworkSettings.Ports {
+		portKeys = append(portKeys, key)
+	}
+	sort.Strings(portKeys)
+
+	// Docker lists IPv4 and IPv6 bindings separately; keep one of each
+	seen := make(map[backend.PortMapping]bool)
+	for _, key := range portKeys {
+		containerPort, protocol, _ := strings.Cut(key, "/")
+		bindings := raw.NetworkSettings.Ports[key]
+		if len(bindings) == 0 {
+			details.Ports = append(details.Ports, backend.PortMapping{
+				ContainerPort: containerPort,
+				Protocol:      protocol,
+			})
+			continue
+		}
+		for _, b := range bindings {
+			pm := backend.PortMapping{
+				HostIP:        normalizeHostIP(b.HostIP),
+				HostPort:      b.HostPort,
+				ContainerPort: containerPort,
+				Protocol:      protocol,
+			}
+			if seen[pm] {
+				continue
+			}
+			seen[pm] = true
+			details.Ports = append(details.Ports, pm)
+		}
+	}
+
+	if len(raw.NetworkSettings.Networks) > 0 {
+		details.Networks = make(map[string]backend.NetworkAttachment)
+		for name, n := range raw.NetworkSettings.Networks {
+			details.Networks[name] = backend.NetworkAttachment{
+				NetworkID:  n.NetworkID,
+				IPAddress:  n.IPAddress,
+				Gateway:    n.Gateway,
+				MacAddress: n.MacAddress,
+				Aliases:    n.Aliases,
+			}
+		}
+	}
+
+	return details, nil
+}
+
+// inspectContainer fetches Docker's raw inspect response for a container
+func (c *Client) inspectContainer(ctx context.Context, endpointID int, containerID string) (*rawContainer, error) {
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/containers/%s/json", c.BaseURL, endpointID, containerID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect container: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("container %s %w", containerID, backend.ErrNotFound)
+	}
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	var raw rawContainer
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &raw, nil
+}
+
+// parseDockerTime parses Docker's RFC 3339 timestamps, mapping Docker's
+// "0001-01-01T00:00:00Z" placeholder and invalid values to the zero time
+func parseDockerTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,113 @@
+package portainer
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+)
+
+// Stream identifiers used in Docker's multiplexed stream framing
+const (
+	streamStdin  = 0
+	streamStdout = 1
+	streamStderr = 2
+)
+
+func (c *Client) ContainerLogs(ctx context.Context, containerID string, opts backend.LogsOptions, stdout, stderr io.Writer) error {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	// TTY containers write a raw stream; all others use multiplexed framing
+	container, err := c.inspectContainer(ctx, endpointID, containerID)
+	if err != nil {
+		return err
+	}
+	tty := container.Config.Tty
+
+	query := url.Values{}
+	query.Set("stdout", "true")
+	query.Set("stderr", "true")
+	query.Set("follow", fmt.Sprintf("%t", opts.Follow))
+	query.Set("timestamps", fmt.Sprintf("%t", opts.Timestamps))
+	if opts.Tail != "" {
+		query.Set("tail", opts.Tail)
+	}
+	if opts.Since != "" {
+		query.Set("since", opts.Since)
+	}
+	if opts.Until != "" {
+		query.Set("until", opts.Until)
+	}
+
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/containers/%s/logs?%s",
+		c.BaseURL, endpointID, containerID, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.doStream(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch logs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return err
+	}
+
+	if tty {
+		_, err = io.Copy(stdout, resp.Body)
+	} else {
+		err = demuxStream(resp.Body, stdout, stderr)
+	}
+
+	// A cancelled follow is the normal way to stop streaming
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to read logs: %w", err)
+	}
+
+	return nil
+}
+
+// demuxStream splits Docker's multiplexed stream into stdout and stderr.
+// Each frame has an 8-byte header: the stream ID, three padding bytes and
+// the big-endian payload length.
+func demuxStream(r io.Reader, stdout, stderr io.Writer) error {
+	header := make([]byte, 8)
+
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		size := int64(binary.BigEndian.Uint32(header[4:]))
+
+		var dst io.Writer
+		switch header[0] {
+		case streamStdout:
+			dst = stdout
+		case streamStderr:
+			dst = stderr
+		case streamStdin:
+			dst = io.Discard
+		default:
+			return fmt.Errorf("unexpected stream ID %d in log framing", header[0])
+		}
+
+		if _, err := io.CopyN(dst, r, size); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,345 @@
+package portainer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+)
+
+// rawNetwork is Docker's network representation
+type rawNetwork struct {
+	ID         string `json:"Id"`
+	Name       string `json:"Name"`
+	Driver     string `json:"Driver"`
+	Scope      string `json:"Scope"`
+	Internal   bool   `json:"Internal"`
+	Attachable bool   `json:"Attachable"`
+	Created    string `json:"Created"`
+	IPAM       struct {
+		Config []struct {
+			Subnet  string `json:"Subnet"`
+			Gateway string `json:"Gateway"`
+			IPRange string `json:"IPRange"`
+		} `json:"Config"`
+	} `json:"IPAM"`
+	Labels     map[string]string `json:"Labels"`
+	Options    map[string]string `json:"Options"`
+	Containers map[string]struct {
+		Name        string `json:"Name"`
+		IPv4Address string `json:"IPv4Address"`
+		IPv6Address string `json:"IPv6Address"`
+		MacAddress  string `json:"MacAddress"`
+	} `json:"Containers"`
+}
+
+func (n rawNetwork) toNetwork() backend.Network {
+	network := backend.Network{
+		ID:         n.ID,
+		Name:       n.Name,
+		Driver:     n.Driver,
+		Scope:      n.Scope,
+		Internal:   n.Internal,
+		Attachable: n.Attachable,
+		Created:    parseDockerTime(n.Created),
+		Labels:     n.Labels,
+		Options:    n.Options,
+	}
+
+	for _, cfg := range n.IPAM.Config {
+		network.Subnets = append(network.Subnets, backend.NetworkSubnet{
+			Subnet:  cfg.Subnet,
+			Gateway: cfg.Gateway,
+			IPRange: cfg.IPRange,
+		})
+	}
+
+	for id, container := range n.Containers {
+		network.Containers = append(network.Containers, backend.NetworkContainer{
+			ID:          id,
+			Name:        container.Name,
+			IPv4Address: container.IPv4Address,
+			IPv6Address: container.IPv6Address,
+			MacAddress:  container.MacAddress,
+		})
+	}
+	sort.Slice(network.Containers, func(i, j int) bool {
+		return network.Containers[i].Name < network.Containers[j].Name
+	})
+
+	return network
+}
+
+func (c *Client) ListNetworks(ctx context.Context, filters map[string][]string) ([]backend.Network, error) {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	query := url.Values{}
+	if len(filters) > 0 {
+		encoded, err := json.Marshal(filters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode filters: %w", err)
+		}
+		query.Set("filters", string(encoded))
+	}
+
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/networks?%s", c.BaseURL, endpointID, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch networks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	var raw []rawNetwork
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	networks := make([]backend.Network, len(raw))
+	for i, n := range raw {
+		networks[i] = n.toNetwork()
+	}
+
+	sort.Slice(networks, func(i, j int) bool { return networks[i].Name < networks[j].Name })
+	return networks, nil
+}
+
+func (c *Client) CreateNetwork(ctx context.Context, opts backend.NetworkCreateOptions) (*backend.Network, error) {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/networks/create", c.BaseURL, endpointID)
+
+	payload := map[string]interface{}{
+		"Name":           opts.Name,
+		"Driver":         opts.Driver,
+		"Internal":       opts.Internal,
+		"Attachable":     opts.Attachable,
+		"Labels":         opts.Labels,
+		"Options":        opts.Options,
+		"CheckDuplicate": true,
+	}
+
+	if opts.Subnet != "" {
+		payload["IPAM"] = map[string]interface{}{
+			"Driver": "default",
+			"Config": []map[string]string{{
+				"Subnet":  opts.Subnet,
+				"Gateway": opts.Gateway,
+				"IPRange": opts.IPRange,
+			}},
+		}
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusConflict {
+		return nil, fmt.Errorf("network %s already exists", opts.Name)
+	}
+
+	if err := checkResponse(resp, http.StatusCreated, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		ID string `json:"Id"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	raw, err := c.inspectNetwork(ctx, endpointID, result.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	network := raw.toNetwork()
+	return &network, nil
+}
+
+func (c *Client) InspectNetwork(ctx context.Context, network string) (*backend.Network, error) {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	raw, err := c.inspectNetwork(ctx, endpointID, network)
+	if err != nil {
+		return nil, err
+	}
+
+	result := raw.toNetwork()
+	return &result, nil
+}
+
+// inspectNetwork fetches Docker's raw inspect response for a network
+func (c *Client) inspectNetwork(ctx context.Context, endpointID int, network string) (*rawNetwork, error) {
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/networks/%s", c.BaseURL, endpointID, url.PathEscape(network))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect network: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("network %s %w", network, backend.ErrNotFound)
+	}
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	var raw rawNetwork
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &raw, nil
+}
+
+func (c *Client) RemoveNetwork(ctx context.Context, network string) error {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/networks/%s", c.BaseURL, endpointID, url.PathEscape(network))
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return fmt.Errorf("network %s not found", network)
+	case http.StatusForbidden:
+		return fmt.Errorf("network %s is a pre-defined network and cannot be removed", network)
+	}
+
+	if err := checkResponse(resp, http.StatusNoContent, http.StatusOK); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) ConnectNetwork(ctx context.Context, network, containerID string, opts backend.NetworkEndpointOptions) error {
+	payload := map[string]interface{}{
+		"Container":      containerID,
+		"EndpointConfig": endpointPayload(opts),
+	}
+
+	return c.networkAction(ctx, network, "connect", payload)
+}
+
+func (c *Client) DisconnectNetwork(ctx context.Context, network, containerID string, force bool) error {
+	payload := map[string]interface{}{
+		"Container": containerID,
+		"Force":     force,
+	}
+
+	return c.networkAction(ctx, network, "disconnect", payload)
+}
+
+// networkAction posts a connect or disconnect request for a network
+func (c *Client) networkAction(ctx context.Context, network, action string, payload map[string]interface{}) error {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/networks/%s/%s", c.BaseURL, endpointID, url.PathEscape(network), action)
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("network %s or container %s not found", network, payload["Container"])
+	}
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// endpointPayload converts endpoint options into Docker's EndpointSettings format
+func endpointPayload(opts backend.NetworkEndpointOptions) map[string]interface{} {
+	endpoint := map[string]interface{}{}
+
+	if len(opts.Aliases) > 0 {
+		endpoint["Aliases"] = opts.Aliases
+	}
+	if opts.IPv4Address != "" {
+		endpoint["IPAMConfig"] = map[string]string{
+			"IPv4Address": opts.IPv4Address,
+		}
+	}
+
+	return endpoint
+}
@@ -7,16 +7,38 @@ import (
     "fmt"
     "io"
     "net/http"
+    "net/url"
+    "strconv"
     "strings"
+    "sync"
     "time"
 
     "github.com/Elias-Larsson/remdoc/internal/backend"
 )
 
+// Auth modes reported by AuthMode
+const (
+    AuthModeJWT    = "jwt"
+    AuthModeAPIKey = "api-key"
+)
+
 type Client struct {
     BaseURL    string
     JWT        string
+    APIKey     string // Portainer access token; takes precedence over JWT when set
+    Endpoint   string // Endpoint ID or name; empty selects the first Docker endpoint
     HTTPClient *http.Client
+
+    // Reauthenticate, if set, is called when a JWT-authenticated request is
+    // rejected with 401. It returns a fresh JWT and the request is retried once.
+    Reauthenticate func(ctx context.Context) (string, error)
+
+    endpointID int // Resolved endpoint ID, cached after the first lookup
+
+    // authMu guards JWT while requests run concurrently, so a rejected token
+    // is refreshed (and the user prompted) only once
+    authMu  sync.Mutex
+    authErr error // Error of the last failed re-authentication, if any
 }
 
 func NewClient(baseURL, jwt string) *Client {
@@ -29,6 +51,112 @@ func NewClient(baseURL, jwt string) *Client {
     }
 }
 
+// NewAPIKeyClient returns a client that authenticates with a Portainer access token
+func NewAPIKeyClient(baseURL, apiKey string) *Client {
+    c := NewClient(baseURL, "")
+    c.APIKey = apiKey
+    return c
+}
+
+// AuthMode reports whether the client authenticates with an access token or a JWT
+func (c *Client) AuthMode() string {
+    if c.APIKey != "" {
+        return AuthModeAPIKey
+    }
+    return AuthModeJWT
+}
+
+// authorize sets the authentication header for the configured auth mode and
+// returns the JWT it used, if any
+func (c *Client) authorize(req *http.Request) string {
+    if c.APIKey != "" {
+        req.Header.Set("X-API-Key", c.APIKey)
+        return ""
+    }
+
+    c.authMu.Lock()
+    jwt := c.JWT
+    c.authMu.Unlock()
+
+    req.Header.Set("Authorization", "Bearer "+jwt)
+    return jwt
+}
+
+// do authorizes and sends a request, re-authenticating once on 401 when possible
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+    return c.send(c.HTTPClient, req)
+}
+
+// doStream is like do but without the client timeout, for long-lived
+// responses such as followed logs; the request context bounds it instead
+func (c *Client) doStream(req *http.Request) (*http.Response, error) {
+    streamClient := &http.Client{
+        Transport:     c.HTTPClient.Transport,
+        CheckRedirect: c.HTTPClient.CheckRedirect,
+        Jar:           c.HTTPClient.Jar,
+    }
+    return c.send(streamClient, req)
+}
+
+func (c *Client) send(httpClient *http.Client, req *http.Request) (*http.Response, error) {
+    jwt := c.authorize(req)
+
+    resp, err := httpClient.Do(req)
+    if err != nil || resp.StatusCode != http.StatusUnauthorized || c.AuthMode() != AuthModeJWT {
+        return resp, err
+    }
+
+    if c.Reauthenticate != nil && (req.Body == nil || req.GetBody != nil) {
+        resp.Body.Close()
+
+        if err := c.refreshJWT(req.Context(), jwt); err != nil {
+            return nil, err
+        }
+
+        retry := req.Clone(req.Context())
+        if req.GetBody != nil {
+            body, err := req.GetBody()
+            if err != nil {
+                return nil, fmt.Errorf("failed to rewind request body: %w", err)
+            }
+            retry.Body = body
+        }
+        c.authorize(retry)
+
+        return httpClient.Do(retry)
+    }
+
+    if expiry, err := TokenExpiry(jwt); err == nil && time.Now().After(expiry) {
+        resp.Body.Close()
+        return nil, fmt.Errorf("session expired at %s (run 'remdoc login' to re-authenticate)", expiry.Local().Format(time.RFC1123))
+    }
+
+    return resp, nil
+}
+
+// refreshJWT replaces a JWT the server rejected. Requests rejected with the
+// same token wait for a single re-authentication and share its result.
+func (c *Client) refreshJWT(ctx context.Context, rejected string) error {
+    c.authMu.Lock()
+    defer c.authMu.Unlock()
+
+    if c.JWT != rejected {
+        return nil
+    }
+    if c.authErr != nil {
+        return c.authErr
+    }
+
+    jwt, err := c.Reauthenticate(ctx)
+    if err != nil {
+        c.authErr = fmt.Errorf("re-authentication failed: %w", err)
+        return c.authErr
+    }
+    c.JWT = jwt
+
+    return nil
+}
+
 // checkResponse validates the HTTP response and returns an error if unexpected
 func checkResponse(resp *http.Response, expectedCodes ...int) error {
     for _, code := range expectedCodes {
@@ -46,15 +174,16 @@ func (c *Client) Validate(ctx context.Context) error {
         return fmt.Errorf("failed to create request: %w", err)
     }
 
-    req.Header.Set("Authorization", "Bearer "+c.JWT)
-
-    resp, err := c.HTTPClient.Do(req)
+    resp, err := c.do(req)
     if err != nil {
         return fmt.Errorf("failed to connect to Portainer: %w", err)
     }
     defer resp.Body.Close()
 
     if resp.StatusCode == http.StatusUnauthorized {
+        if c.AuthMode() == AuthModeAPIKey {
+            return fmt.Errorf("invalid API key (unauthorized)")
+        }
         return fmt.Errorf("invalid JWT token (unauthorized)")
     }
 
@@ -65,21 +194,32 @@ func (c *Client) Validate(ctx context.Context) error {
     return nil
 }
 
-func (c *Client) ListContainers(ctx context.Context) ([]backend.Container, error) {
-    endpointID, err := c.getFirstEndpoint(ctx)
+func (c *Client) ListContainers(ctx context.Context, opts backend.ListOptions) ([]backend.Container, error) {
+    endpointID, err := c.resolveEndpoint(ctx)
     if err != nil {
         return nil, fmt.Errorf("failed to get endpoint: %w", err)
     }
 
-    url := fmt.Sprintf("%s/api/endpoints/%d/docker/containers/json?all=true", c.BaseURL, endpointID)
+    query := url.Values{}
+    query.Set("all", strconv.FormatBool(opts.All))
+    if opts.Limit > 0 {
+        query.Set("limit", strconv.Itoa(opts.Limit))
+    }
+    if len(opts.Filters) > 0 {
+        filters, err := json.Marshal(opts.Filters)
+        if err != nil {
+            return nil, fmt.Errorf("failed to encode filters: %w", err)
+        }
+        query.Set("filters", string(filters))
+    }
+
+    url := fmt.Sprintf("%s/api/endpoints/%d/docker/containers/json?%s", c.BaseURL, endpointID, query.Encode())
     req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
     if err != nil {
         return nil, fmt.Errorf("failed to create request: %w", err)
     }
 
-    req.Header.Set("Authorization", "Bearer "+c.JWT)
-
-    resp, err := c.HTTPClient.Do(req)
+    resp, err := c.do(req)
     if err != nil {
         return nil, fmt.Errorf("failed to fetch containers: %w", err)
     }
@@ -90,11 +230,18 @@ func (c *Client) ListContainers(ctx context.Context) ([]backend.Container, error
     }
 
     var rawContainers []struct {
-        ID     string   `json:"Id"`
-        Names  []string `json:"Names"`
-        Image  string   `json:"Image"`
-        State  string   `json:"State"`
-        Status string   `json:"Status"`
+        ID      string   `json:"Id"`
+        Names   []string `json:"Names"`
+        Image   string   `json:"Image"`
+        State   string   `json:"State"`
+        Status  string   `json:"Status"`
+        Created int64    `json:"Created"`
+        Ports   []struct {
+            IP          string `json:"IP"`
+            PrivatePort int    `json:"PrivatePort"`
+            PublicPort  int    `json:"PublicPort"`
+            Type        string `json:"Type"`
+        } `json:"Ports"`
     }
 
     if err := json.NewDecoder(resp.Body).Decode(&rawContainers); err != nil {
@@ -108,53 +255,49 @@ func (c *Client) ListContainers(ctx context.Context) ([]backend.Container, error
             name = strings.TrimPrefix(raw.Names[0], "/")
         }
 
+        // Docker lists IPv4 and IPv6 bindings separately; keep one of each
+        var ports []backend.PortMapping
+        seen := make(map[backend.PortMapping]bool)
+        for _, p := range raw.Ports {
+            pm := backend.PortMapping{
+                HostIP:        normalizeHostIP(p.IP),
+                ContainerPort: strconv.Itoa(p.PrivatePort),
+                Protocol:      p.Type,
+            }
+            if p.PublicPort != 0 {
+                pm.HostPort = strconv.Itoa(p.PublicPort)
+            }
+            if seen[pm] {
+                continue
+            }
+            seen[pm] = true
+            ports = append(ports, pm)
+        }
+
         containers[i] = backend.Container{
-            ID:     raw.ID[:12],
-            Name:   name,
-            Image:  raw.Image,
-            State:  raw.State,
-            Status: raw.Status,
+            ID:      raw.ID[:12],
+            Name:    name,
+            Image:   raw.Image,
+            State:   raw.State,
+            Status:  raw.Status,
+            Created: time.Unix(raw.Created, 0),
+            Ports:   ports,
         }
     }
 
     return containers, nil
 }
 
-func (c *Client) getFirstEndpoint(ctx context.Context) (int, error) {
-    req, err := http.NewRequestWithContext(ctx, "GET", c.BaseURL+"/api/endpoints", nil)
-    if err != nil {
-        return 0, fmt.Errorf("failed to create request: %w", err)
-    }
-
-    req.Header.Set("Authorization", "Bearer "+c.JWT)
-
-    resp, err := c.HTTPClient.Do(req)
-    if err != nil {
-        return 0, fmt.Errorf("failed to fetch endpoints: %w", err)
-    }
-    defer resp.Body.Close()
-
-    if err := checkResponse(resp, http.StatusOK); err != nil {
-        return 0, err
-    }
-
-    var endpoints []struct {
-        ID int `json:"Id"`
-    }
-
-    if err := json.NewDecoder(resp.Body).Decode(&endpoints); err != nil {
-        return 0, fmt.Errorf("failed to parse endpoints: %w", err)
-    }
-
-    if len(endpoints) == 0 {
-        return 0, fmt.Errorf("no Docker endpoints configured in Portainer")
+// normalizeHostIP maps Docker's "bind on all interfaces" addresses to ""
+func normalizeHostIP(ip string) string {
+    if ip == "0.0.0.0" || ip == "::" {
+        return ""
     }
-
-    return endpoints[0].ID, nil
+    return ip
 }
 
 func (c *Client) DeployContainer(ctx context.Context, opts backend.DeployOptions) (*backend.Container, error) {
-    endpointID, err := c.getFirstEndpoint(ctx)
+    endpointID, err := c.resolveEndpoint(ctx)
     if err != nil {
         return nil, fmt.Errorf("failed to get endpoint: %w", err)
     }
@@ -195,9 +338,11 @@ func (c *Client) createContainer(ctx context.Context, endpointID int, opts backe
         containerPortKey := pm.ContainerPort + "/" + protocol
         exposedPorts[containerPortKey] = struct{}{}
 
-        portBindings[containerPortKey] = []map[string]string{
-            {"HostPort": pm.HostPort},
-        }
+        // A container port may be published on several host ports/interfaces
+        portBindings[containerPortKey] = append(portBindings[containerPortKey], map[string]string{
+            "HostIp":   pm.HostIP,
+            "HostPort": pm.HostPort,
+        })
     }
 
     var envVars []string
@@ -205,17 +350,76 @@ func (c *Client) createContainer(ctx context.Context, endpointID int, opts backe
         envVars = append(envVars, fmt.Sprintf("%s=%s", key, value))
     }
 
+    var ulimits []map[string]interface{}
+    for _, u := range opts.Ulimits {
+        ulimits = append(ulimits, map[string]interface{}{
+            "Name": u.Name,
+            "Soft": u.Soft,
+            "Hard": u.Hard,
+        })
+    }
+
+    hostConfig := map[string]interface{}{
+        "PortBindings": portBindings,
+        "RestartPolicy": map[string]interface{}{
+            "Name": opts.Restart,
+        },
+        "AutoRemove":     opts.AutoRemove,
+        "Binds":          opts.Binds,
+        "Mounts":         mountsPayload(opts.Mounts),
+        "NetworkMode":    opts.Network,
+        "Memory":         opts.Memory,
+        "NanoCpus":       opts.NanoCPUs,
+        "CapAdd":         opts.CapAdd,
+        "CapDrop":        opts.CapDrop,
+        "ReadonlyRootfs": opts.ReadOnly,
+        "Privileged":     opts.Privileged,
+        "SecurityOpt":    opts.SecurityOpt,
+        "Ulimits":        ulimits,
+    }
+
+    if opts.PidsLimit != 0 {
+        hostConfig["PidsLimit"] = opts.PidsLimit
+    }
+
+    if opts.LogDriver != "" || len(opts.LogOptions) > 0 {
+        hostConfig["LogConfig"] = map[string]interface{}{
+            "Type":   opts.LogDriver,
+            "Config": opts.LogOptions,
+        }
+    }
+
     payload := map[string]interface{}{
         "Image":        opts.Image,
         "ExposedPorts": exposedPorts,
         "Env":          envVars,
-        "HostConfig": map[string]interface{}{
-            "PortBindings": portBindings,
-            "RestartPolicy": map[string]interface{}{
-                "Name": opts.Restart,
+        "Hostname":     opts.Hostname,
+        "User":         opts.User,
+        "WorkingDir":   opts.WorkingDir,
+        "HostConfig":   hostConfig,
+    }
+
+    // Leaving these out keeps the image's ENTRYPOINT and CMD
+    if opts.Entrypoint != nil {
+        payload["Entrypoint"] = opts.Entrypoint
+    }
+    if opts.Cmd != nil {
+        payload["Cmd"] = opts.Cmd
+    }
+
+    if opts.Healthcheck != nil {
+        payload["Healthcheck"] = healthcheckPayload(opts.Healthcheck)
+    }
+
+    if opts.Network != "" {
+        payload["NetworkingConfig"] = map[string]interface{}{
+            "EndpointsConfig": map[string]interface{}{
+                opts.Network: endpointPayload(backend.NetworkEndpointOptions{
+                    Aliases:     opts.Aliases,
+                    IPv4Address: opts.IPAddress,
+                }),
             },
-            "AutoRemove": opts.AutoRemove,
-        },
+        }
     }
 
     jsonData, err := json.Marshal(payload)
@@ -228,10 +432,9 @@ func (c *Client) createContainer(ctx context.Context, endpointID int, opts backe
         return "", fmt.Errorf("failed to create request: %w", err)
     }
 
-    req.Header.Set("Authorization", "Bearer "+c.JWT)
     req.Header.Set("Content-Type", "application/json")
 
-    resp, err := c.HTTPClient.Do(req)
+    resp, err := c.do(req)
     if err != nil {
         return "", fmt.Errorf("failed to send request: %w", err)
     }
@@ -253,6 +456,82 @@ func (c *Client) createContainer(ctx context.Context, endpointID int, opts backe
     return result.ID, nil
 }
 
+// healthcheckPayload converts a healthcheck into Docker's HealthConfig
+// format, leaving out unset fields so the image's values apply
+func healthcheckPayload(h *backend.Healthcheck) map[string]interface{} {
+    payload := map[string]interface{}{}
+
+    if len(h.Test) > 0 {
+        payload["Test"] = h.Test
+    }
+    if h.Interval > 0 {
+        payload["Interval"] = h.Interval.Nanoseconds()
+    }
+    if h.Timeout > 0 {
+        payload["Timeout"] = h.Timeout.Nanoseconds()
+    }
+    if h.StartPeriod > 0 {
+        payload["StartPeriod"] = h.StartPeriod.Nanoseconds()
+    }
+    if h.Retries > 0 {
+        payload["Retries"] = h.Retries
+    }
+
+    return payload
+}
+
+// mountsPayload converts mounts into Docker's HostConfig.Mounts format
+func mountsPayload(mounts []backend.Mount) []map[string]interface{} {
+    payload := make([]map[string]interface{}, 0, len(mounts))
+
+    for _, m := range mounts {
+        mount := map[string]interface{}{
+            "Type":     m.Type,
+            "Target":   m.Target,
+            "ReadOnly": m.ReadOnly,
+        }
+        if m.Source != "" {
+            mount["Source"] = m.Source
+        }
+
+        switch m.Type {
+        case "bind":
+            if m.BindPropagation != "" {
+                mount["BindOptions"] = map[string]interface{}{
+                    "Propagation": m.BindPropagation,
+                }
+            }
+        case "volume":
+            volumeOptions := map[string]interface{}{
+                "NoCopy": m.VolumeNoCopy,
+            }
+            if len(m.VolumeLabels) > 0 {
+                volumeOptions["Labels"] = m.VolumeLabels
+            }
+            if m.VolumeDriver != "" || len(m.VolumeOptions) > 0 {
+                volumeOptions["DriverConfig"] = map[string]interface{}{
+                    "Name":    m.VolumeDriver,
+                    "Options": m.VolumeOptions,
+                }
+            }
+            mount["VolumeOptions"] = volumeOptions
+        case "tmpfs":
+            tmpfsOptions := map[string]interface{}{}
+            if m.TmpfsSize > 0 {
+                tmpfsOptions["SizeBytes"] = m.TmpfsSize
+            }
+            if m.TmpfsMode != 0 {
+                tmpfsOptions["Mode"] = m.TmpfsMode
+            }
+            mount["TmpfsOptions"] = tmpfsOptions
+        }
+
+        payload = append(payload, mount)
+    }
+
+    return payload
+}
+
 func (c *Client) startContainer(ctx context.Context, endpointID int, containerID string) error {
     url := fmt.Sprintf("%s/api/endpoints/%d/docker/containers/%s/start", c.BaseURL, endpointID, containerID)
 
@@ -261,9 +540,7 @@ func (c *Client) startContainer(ctx context.Context, endpointID int, containerID
         return fmt.Errorf("failed to create request: %w", err)
     }
 
-    req.Header.Set("Authorization", "Bearer "+c.JWT)
-
-    resp, err := c.HTTPClient.Do(req)
+    resp, err := c.do(req)
     if err != nil {
         return fmt.Errorf("failed to send request: %w", err)
     }
@@ -277,7 +554,7 @@ func (c *Client) startContainer(ctx context.Context, endpointID int, containerID
 }
 
 func (c *Client) RemoveContainer(ctx context.Context, containerID string, force bool) error {
-    endpointID, err := c.getFirstEndpoint(ctx)
+    endpointID, err := c.resolveEndpoint(ctx)
     if err != nil {
         return fmt.Errorf("failed to get endpoint: %w", err)
     }
@@ -290,9 +567,7 @@ func (c *Client) RemoveContainer(ctx context.Context, containerID string, force
         return fmt.Errorf("failed to create request: %w", err)
     }
 
-    req.Header.Set("Authorization", "Bearer "+c.JWT)
-
-    resp, err := c.HTTPClient.Do(req)
+    resp, err := c.do(req)
     if err != nil {
         return fmt.Errorf("failed to send request: %w", err)
     }
@@ -306,7 +581,7 @@ func (c *Client) RemoveContainer(ctx context.Context, containerID string, force
 }
 
 func (c *Client) StopContainer(ctx context.Context, containerID string) error {
-    endpointID, err := c.getFirstEndpoint(ctx)
+    endpointID, err := c.resolveEndpoint(ctx)
     if err != nil {
         return fmt.Errorf("failed to get endpoint: %w", err)
     }
@@ -318,9 +593,7 @@ func (c *Client) StopContainer(ctx context.Context, containerID string) error {
         return fmt.Errorf("failed to create request: %w", err)
     }
 
-    req.Header.Set("Authorization", "Bearer "+c.JWT)
-
-    resp, err := c.HTTPClient.Do(req)
+    resp, err := c.do(req)
     if err != nil {
         return fmt.Errorf("failed to send request: %w", err)
     }
@@ -334,7 +607,7 @@ func (c *Client) StopContainer(ctx context.Context, containerID string) error {
 }
 
 func (c *Client) StartContainer(ctx context.Context, containerID string) error {
-    endpointID, err := c.getFirstEndpoint(ctx)
+    endpointID, err := c.resolveEndpoint(ctx)
     if err != nil {
         return fmt.Errorf("failed to get endpoint: %w", err)
     }
@@ -342,7 +615,7 @@ func (c *Client) StartContainer(ctx context.Context, containerID string) error {
     return c.startContainer(ctx, endpointID, containerID)
 }
 
-func (c *Client) DeployComposeStack(ctx context.Context, name string, composeContent string) (int, error) {
+func (c *Client) DeployComposeStack(ctx context.Context, name string, composeContent string, env []backend.EnvVar) (int, error) {
     if strings.TrimSpace(name) == "" {
         return 0, fmt.Errorf("stack name cannot be empty")
     }
@@ -350,17 +623,21 @@ func (c *Client) DeployComposeStack(ctx context.Context, name string, composeCon
         return 0, fmt.Errorf("compose content cannot be empty")
     }
 
-    endpointID, err := c.getFirstEndpoint(ctx)
+    endpointID, err := c.resolveEndpoint(ctx)
     if err != nil {
         return 0, fmt.Errorf("failed to get endpoint: %w", err)
     }
 
     url := fmt.Sprintf("%s/api/stacks?type=2&method=string&endpointId=%d", c.BaseURL, endpointID)
 
+    if env == nil {
+        env = []backend.EnvVar{}
+    }
+
     payload := map[string]interface{}{
         "Name":             name,
         "StackFileContent": composeContent,
-        "Env":              []interface{}{},
+        "Env":              env,
     }
 
     jsonData, err := json.Marshal(payload)
@@ -373,10 +650,11 @@ func (c *Client) DeployComposeStack(ctx context.Context, name string, composeCon
         return 0, fmt.Errorf("failed to create request: %w", err)
     }
 
-    req.Header.Set("Authorization", "Bearer "+c.JWT)
     req.Header.Set("Content-Type", "application/json")
 
-    resp, err := c.HTTPClient.Do(req)
+    // Portainer pulls images and runs compose before responding, so only
+    // ctx bounds the request
+    resp, err := c.doStream(req)
     if err != nil {
         return 0, fmt.Errorf("failed to send request: %w", err)
     }
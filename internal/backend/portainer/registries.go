@@ -0,0 +1,131 @@
+package portainer
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+)
+
+// Registry represents a container registry configured in Portainer
+type Registry struct {
+	ID             int    `json:"Id"`
+	Name           string `json:"Name"`
+	Type           int    `json:"Type"`
+	URL            string `json:"URL"`
+	Authentication bool   `json:"Authentication"`
+	Username       string `json:"Username"`
+}
+
+// TypeName returns a human-readable name for the registry type
+func (r Registry) TypeName() string {
+	switch r.Type {
+	case 1:
+		return "quay"
+	case 2:
+		return "azure"
+	case 3:
+		return "custom"
+	case 4:
+		return "gitlab"
+	case 5:
+		return "proget"
+	case 6:
+		return "dockerhub"
+	case 7:
+		return "ecr"
+	case 8:
+		return "github"
+	default:
+		return "unknown"
+	}
+}
+
+// ListRegistries returns the registries available to the selected endpoint
+func (c *Client) ListRegistries(ctx context.Context) ([]Registry, error) {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/endpoints/%d/registries", c.BaseURL, endpointID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch registries: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	var registries []Registry
+	if err := json.NewDecoder(resp.Body).Decode(&registries); err != nil {
+		return nil, fmt.Errorf("failed to parse registries: %w", err)
+	}
+
+	return registries, nil
+}
+
+// FindRegistry looks up a registry by ID, name or URL
+func (c *Client) FindRegistry(ctx context.Context, ref string) (*Registry, error) {
+	registries, err := c.ListRegistries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ref = strings.TrimSpace(ref)
+
+	// An exact ID match takes precedence over a name that happens to be numeric
+	if id, err := strconv.Atoi(ref); err == nil {
+		for i := range registries {
+			if registries[i].ID == id {
+				return &registries[i], nil
+			}
+		}
+	}
+
+	for i := range registries {
+		if registries[i].Name == ref || registries[i].URL == ref {
+			return &registries[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("registry %q not found (run 'remdoc registry ls' to list available registries)", ref)
+}
+
+// registryAuthHeader encodes credentials for the X-Registry-Auth header.
+// Portainer replaces a registryId with the credentials it stores for that
+// registry; anything else is passed through to Docker, which expects
+// base64url-encoded JSON.
+func registryAuthHeader(auth *backend.RegistryAuth) (string, error) {
+	var payload interface{}
+	if auth.RegistryID != 0 {
+		payload = map[string]int{"registryId": auth.RegistryID}
+	} else {
+		payload = map[string]string{
+			"username":      auth.Username,
+			"password":      auth.Password,
+			"identitytoken": auth.IdentityToken,
+			"serveraddress": auth.ServerAddress,
+		}
+	}
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode registry credentials: %w", err)
+	}
+
+	return base64.URLEncoding.EncodeToString(encoded), nil
+}
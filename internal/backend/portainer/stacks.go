@@ -0,0 +1,486 @@
+package portainer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+)
+
+// rawStack is Portainer's stack representation
+type rawStack struct {
+	ID           int              `json:"Id"`
+	Name         string           `json:"Name"`
+	Type         int              `json:"Type"`
+	EndpointID   int              `json:"EndpointId"`
+	Status       int              `json:"Status"`
+	CreationDate int64            `json:"CreationDate"`
+	CreatedBy    string           `json:"CreatedBy"`
+	UpdateDate   int64            `json:"UpdateDate"`
+	UpdatedBy    string           `json:"UpdatedBy"`
+	Env          []backend.EnvVar `json:"Env"`
+	GitConfig    *struct {
+		URL            string `json:"URL"`
+		ReferenceName  string `json:"ReferenceName"`
+		ConfigFilePath string `json:"ConfigFilePath"`
+		ConfigHash     string `json:"ConfigHash"`
+		Authentication *struct {
+			Username string `json:"Username"`
+		} `json:"Authentication"`
+	} `json:"GitConfig"`
+	AutoUpdate *struct {
+		Interval string `json:"Interval"`
+		Webhook  string `json:"Webhook"`
+	} `json:"AutoUpdate"`
+}
+
+func (s rawStack) toStack() backend.Stack {
+	stack := backend.Stack{
+		ID:         s.ID,
+		Name:       s.Name,
+		EndpointID: s.EndpointID,
+		CreatedBy:  s.CreatedBy,
+		UpdatedBy:  s.UpdatedBy,
+		Env:        s.Env,
+	}
+
+	switch s.Type {
+	case 1:
+		stack.Type = "swarm"
+	case 2:
+		stack.Type = "compose"
+	case 3:
+		stack.Type = "kubernetes"
+	default:
+		stack.Type = "unknown"
+	}
+
+	switch s.Status {
+	case 1:
+		stack.Status = "active"
+	case 2:
+		stack.Status = "inactive"
+	default:
+		stack.Status = "unknown"
+	}
+
+	if g := s.GitConfig; g != nil {
+		stack.Git = &backend.GitSource{
+			URL:    g.URL,
+			Ref:    g.ReferenceName,
+			Path:   g.ConfigFilePath,
+			Commit: g.ConfigHash,
+		}
+		if g.Authentication != nil {
+			stack.Git.Username = g.Authentication.Username
+		}
+		if u := s.AutoUpdate; u != nil && (u.Interval != "" || u.Webhook != "") {
+			stack.Git.AutoUpdate = &backend.StackAutoUpdate{Interval: u.Interval, Webhook: u.Webhook}
+		}
+	}
+
+	if s.CreationDate > 0 {
+		stack.Created = time.Unix(s.CreationDate, 0)
+	}
+	if s.UpdateDate > 0 {
+		stack.Updated = time.Unix(s.UpdateDate, 0)
+	}
+
+	return stack
+}
+
+func (c *Client) ListStacks(ctx context.Context) ([]backend.Stack, error) {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	raw, err := c.listStacks(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	stacks := make([]backend.Stack, len(raw))
+	for i, s := range raw {
+		stacks[i] = s.toStack()
+	}
+
+	return stacks, nil
+}
+
+// listStacks fetches the stacks deployed to an endpoint
+func (c *Client) listStacks(ctx context.Context, endpointID int) ([]rawStack, error) {
+	query := url.Values{}
+	query.Set("filters", fmt.Sprintf(`{"EndpointID":%d}`, endpointID))
+
+	url := fmt.Sprintf("%s/api/stacks?%s", c.BaseURL, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch stacks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	var stacks []rawStack
+	if err := json.NewDecoder(resp.Body).Decode(&stacks); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return stacks, nil
+}
+
+// findStack looks up a stack on an endpoint by ID or name
+func (c *Client) findStack(ctx context.Context, endpointID int, ref string) (*rawStack, error) {
+	stacks, err := c.listStacks(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	ref = strings.TrimSpace(ref)
+
+	// An exact ID match takes precedence over a name that happens to be numeric
+	if id, err := strconv.Atoi(ref); err == nil {
+		for i := range stacks {
+			if stacks[i].ID == id {
+				return &stacks[i], nil
+			}
+		}
+	}
+
+	for i := range stacks {
+		if stacks[i].Name == ref {
+			return &stacks[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("stack %s %w", ref, backend.ErrNotFound)
+}
+
+func (c *Client) InspectStack(ctx context.Context, ref string) (*backend.Stack, error) {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	raw, err := c.findStack(ctx, endpointID, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := c.stackFile(ctx, raw.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	stack := raw.toStack()
+	stack.Content = content
+	return &stack, nil
+}
+
+// stackFile fetches a stack's compose file
+func (c *Client) stackFile(ctx context.Context, stackID int) (string, error) {
+	url := fmt.Sprintf("%s/api/stacks/%d/file", c.BaseURL, stackID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch stack file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return "", err
+	}
+
+	var result struct {
+		StackFileContent string `json:"StackFileContent"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return result.StackFileContent, nil
+}
+
+func (c *Client) UpdateStack(ctx context.Context, ref string, opts backend.StackUpdateOptions) error {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	stack, err := c.findStack(ctx, endpointID, ref)
+	if err != nil {
+		return err
+	}
+
+	// Git-backed stacks can only be redeployed from their repository
+	if stack.GitConfig != nil {
+		if opts.Content != "" {
+			return fmt.Errorf("stack %s is deployed from Git; its compose file comes from the repository", stack.Name)
+		}
+		return c.pullStack(ctx, endpointID, stack, backend.StackPullOptions{Env: opts.Env, Prune: opts.Prune, Pull: opts.Pull})
+	}
+
+	// Portainer replaces both the file and the environment on update
+	content := opts.Content
+	if content == "" {
+		content, err = c.stackFile(ctx, stack.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	env := opts.Env
+	if env == nil {
+		env = stack.Env
+	}
+	if env == nil {
+		env = []backend.EnvVar{}
+	}
+
+	payload := map[string]interface{}{
+		"StackFileContent": content,
+		"Env":              env,
+		"Prune":            opts.Prune,
+		"PullImage":        opts.Pull,
+	}
+
+	url := fmt.Sprintf("%s/api/stacks/%d?endpointId=%d", c.BaseURL, stack.ID, endpointID)
+	return c.stackRequest(ctx, "PUT", url, payload)
+}
+
+func (c *Client) DeployGitStack(ctx context.Context, opts backend.GitStackOptions) (int, error) {
+	if strings.TrimSpace(opts.Name) == "" {
+		return 0, fmt.Errorf("stack name cannot be empty")
+	}
+	if strings.TrimSpace(opts.URL) == "" {
+		return 0, fmt.Errorf("repository URL cannot be empty")
+	}
+
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	env := opts.Env
+	if env == nil {
+		env = []backend.EnvVar{}
+	}
+
+	payload := map[string]interface{}{
+		"Name":                     opts.Name,
+		"RepositoryURL":            opts.URL,
+		"RepositoryReferenceName":  gitReference(opts.Ref),
+		"ComposeFile":              opts.Path,
+		"RepositoryAuthentication": opts.Username != "",
+		"RepositoryUsername":       opts.Username,
+		"RepositoryPassword":       opts.Password,
+		"Env":                      env,
+	}
+	if opts.AutoUpdate != nil {
+		payload["AutoUpdate"] = map[string]interface{}{
+			"Interval": opts.AutoUpdate.Interval,
+			"Webhook":  opts.AutoUpdate.Webhook,
+		}
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/stacks?type=2&method=repository&endpointId=%d", c.BaseURL, endpointID)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	// Portainer clones the repository and runs compose before responding
+	resp, err := c.doStream(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusCreated, http.StatusOK); err != nil {
+		return 0, err
+	}
+
+	var result struct {
+		ID int `json:"Id"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return result.ID, nil
+}
+
+func (c *Client) PullStack(ctx context.Context, ref string, opts backend.StackPullOptions) error {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	stack, err := c.findStack(ctx, endpointID, ref)
+	if err != nil {
+		return err
+	}
+
+	if stack.GitConfig == nil {
+		return fmt.Errorf("stack %s is not deployed from Git", stack.Name)
+	}
+
+	return c.pullStack(ctx, endpointID, stack, opts)
+}
+
+// pullStack asks Portainer to fetch a Git-backed stack's repository and
+// redeploy it
+func (c *Client) pullStack(ctx context.Context, endpointID int, stack *rawStack, opts backend.StackPullOptions) error {
+	reference := stack.GitConfig.ReferenceName
+	if opts.Ref != "" {
+		reference = gitReference(opts.Ref)
+	}
+
+	env := opts.Env
+	if env == nil {
+		env = stack.Env
+	}
+	if env == nil {
+		env = []backend.EnvVar{}
+	}
+
+	// Portainer falls back to the stored password when only a username is sent
+	username := opts.Username
+	if username == "" && stack.GitConfig.Authentication != nil {
+		username = stack.GitConfig.Authentication.Username
+	}
+
+	payload := map[string]interface{}{
+		"RepositoryReferenceName":  reference,
+		"RepositoryAuthentication": username != "",
+		"RepositoryUsername":       username,
+		"RepositoryPassword":       opts.Password,
+		"Env":                      env,
+		"Prune":                    opts.Prune,
+		"PullImage":                opts.Pull,
+	}
+
+	url := fmt.Sprintf("%s/api/stacks/%d/git/redeploy?endpointId=%d", c.BaseURL, stack.ID, endpointID)
+	return c.stackRequest(ctx, "PUT", url, payload)
+}
+
+// StackWebhookURL returns the URL that triggers a redeploy of the Git-backed
+// stack with the given webhook ID
+func (c *Client) StackWebhookURL(webhook string) string {
+	return fmt.Sprintf("%s/api/stacks/webhooks/%s", c.BaseURL, webhook)
+}
+
+// gitReference expands a branch name such as "main" to "refs/heads/main".
+// Full references like "refs/tags/v1.2" are used as given.
+func gitReference(ref string) string {
+	if ref == "" || strings.HasPrefix(ref, "refs/") {
+		return ref
+	}
+	return "refs/heads/" + ref
+}
+
+func (c *Client) StopStack(ctx context.Context, ref string) error {
+	return c.stackAction(ctx, ref, "stop")
+}
+
+func (c *Client) StartStack(ctx context.Context, ref string) error {
+	return c.stackAction(ctx, ref, "start")
+}
+
+// stackAction posts a start or stop request for a stack
+func (c *Client) stackAction(ctx context.Context, ref, action string) error {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	stack, err := c.findStack(ctx, endpointID, ref)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/api/stacks/%d/%s?endpointId=%d", c.BaseURL, stack.ID, action, endpointID)
+	return c.stackRequest(ctx, "POST", url, nil)
+}
+
+func (c *Client) RemoveStack(ctx context.Context, ref string) error {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	stack, err := c.findStack(ctx, endpointID, ref)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/api/stacks/%d?endpointId=%d", c.BaseURL, stack.ID, endpointID)
+	return c.stackRequest(ctx, "DELETE", url, nil)
+}
+
+// stackRequest sends a stack API request with an optional JSON payload.
+// Portainer runs compose synchronously, so these can take a while.
+func (c *Client) stackRequest(ctx context.Context, method, url string, payload interface{}) error {
+	var body *bytes.Buffer
+	if payload != nil {
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode payload: %w", err)
+		}
+		body = bytes.NewBuffer(jsonData)
+	} else {
+		body = &bytes.Buffer{}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.doStream(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusOK, http.StatusNoContent); err != nil {
+		return err
+	}
+
+	return nil
+}
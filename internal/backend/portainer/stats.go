@@ -0,0 +1,155 @@
+package portainer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+)
+
+// rawCPUStats is one CPU sample from Docker's stats endpoint
+type rawCPUStats struct {
+	CPUUsage struct {
+		TotalUsage  uint64   `json:"total_usage"`
+		PercpuUsage []uint64 `json:"percpu_usage"`
+	} `json:"cpu_usage"`
+	SystemUsage uint64 `json:"system_cpu_usage"`
+	OnlineCPUs  uint32 `json:"online_cpus"`
+}
+
+// rawStats is Docker's container stats representation
+type rawStats struct {
+	ID       string      `json:"id"`
+	Name     string      `json:"name"`
+	Read     string      `json:"read"`
+	CPUStats rawCPUStats `json:"cpu_stats"`
+	PreCPU   rawCPUStats `json:"precpu_stats"`
+	Memory   struct {
+		Usage uint64            `json:"usage"`
+		Limit uint64            `json:"limit"`
+		Stats map[string]uint64 `json:"stats"`
+	} `json:"memory_stats"`
+	Networks map[string]struct {
+		RxBytes uint64 `json:"rx_bytes"`
+		TxBytes uint64 `json:"tx_bytes"`
+	} `json:"networks"`
+	BlkioStats struct {
+		IOServiceBytes []struct {
+			Op    string `json:"op"`
+			Value uint64 `json:"value"`
+		} `json:"io_service_bytes_recursive"`
+	} `json:"blkio_stats"`
+	PidsStats struct {
+		Current uint64 `json:"current"`
+	} `json:"pids_stats"`
+}
+
+func (s rawStats) toStats() backend.ContainerStats {
+	stats := backend.ContainerStats{
+		ID:          s.ID,
+		Name:        strings.TrimPrefix(s.Name, "/"),
+		Read:        parseDockerTime(s.Read),
+		CPUPercent:  cpuPercent(s.PreCPU, s.CPUStats),
+		MemoryUsage: memoryUsage(s.Memory.Usage, s.Memory.Stats),
+		MemoryLimit: s.Memory.Limit,
+		PIDs:        s.PidsStats.Current,
+	}
+
+	if stats.MemoryLimit > 0 {
+		stats.MemoryPercent = float64(stats.MemoryUsage) / float64(stats.MemoryLimit) * 100
+	}
+
+	for _, n := range s.Networks {
+		stats.NetworkRx += n.RxBytes
+		stats.NetworkTx += n.TxBytes
+	}
+
+	for _, entry := range s.BlkioStats.IOServiceBytes {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			stats.BlockRead += entry.Value
+		case "write":
+			stats.BlockWrite += entry.Value
+		}
+	}
+
+	return stats
+}
+
+// cpuPercent computes CPU usage between two samples the way docker stats
+// does: the container's share of the host's CPU time, scaled by the number of
+// CPUs, so a container using two full cores reports 200%
+func cpuPercent(pre, cur rawCPUStats) float64 {
+	cpuDelta := float64(cur.CPUUsage.TotalUsage) - float64(pre.CPUUsage.TotalUsage)
+	systemDelta := float64(cur.SystemUsage) - float64(pre.SystemUsage)
+	if cpuDelta <= 0 || systemDelta <= 0 {
+		return 0
+	}
+
+	cpus := float64(cur.OnlineCPUs)
+	if cpus == 0 {
+		cpus = float64(len(cur.CPUUsage.PercpuUsage))
+	}
+
+	return cpuDelta / systemDelta * cpus * 100
+}
+
+// memoryUsage excludes reclaimable page cache from the reported usage, like
+// docker stats. cgroup v1 reports it as total_inactive_file, v2 as inactive_file.
+func memoryUsage(usage uint64, stats map[string]uint64) uint64 {
+	inactive, ok := stats["total_inactive_file"]
+	if !ok {
+		inactive = stats["inactive_file"]
+	}
+	if inactive < usage {
+		return usage - inactive
+	}
+	return usage
+}
+
+func (c *Client) ContainerStats(ctx context.Context, containerID string, stream bool, onStats func(backend.ContainerStats)) error {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/containers/%s/stats?stream=%t",
+		c.BaseURL, endpointID, containerID, stream)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.doStream(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch stats: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("container %s %w", containerID, backend.ErrNotFound)
+	}
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var raw rawStats
+		if err := decoder.Decode(&raw); err != nil {
+			// Streams end when the container stops or ctx is cancelled
+			if err == io.EOF || ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to parse stats: %w", err)
+		}
+
+		onStats(raw.toStats())
+	}
+}
@@ -0,0 +1,318 @@
+package portainer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+)
+
+// rawVolume is Docker's volume representation
+type rawVolume struct {
+	Name       string            `json:"Name"`
+	Driver     string            `json:"Driver"`
+	Mountpoint string            `json:"Mountpoint"`
+	Scope      string            `json:"Scope"`
+	CreatedAt  string            `json:"CreatedAt"`
+	Labels     map[string]string `json:"Labels"`
+	Options    map[string]string `json:"Options"`
+}
+
+func (v rawVolume) toVolume(users map[string][]string) backend.Volume {
+	usedBy := users[v.Name]
+	if usedBy == nil {
+		usedBy = []string{}
+	}
+
+	return backend.Volume{
+		Name:       v.Name,
+		Driver:     v.Driver,
+		Mountpoint: v.Mountpoint,
+		Scope:      v.Scope,
+		CreatedAt:  parseDockerTime(v.CreatedAt),
+		Labels:     v.Labels,
+		Options:    v.Options,
+		UsedBy:     usedBy,
+	}
+}
+
+func (c *Client) ListVolumes(ctx context.Context, filters map[string][]string) ([]backend.Volume, error) {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	query := url.Values{}
+	if len(filters) > 0 {
+		encoded, err := json.Marshal(filters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode filters: %w", err)
+		}
+		query.Set("filters", string(encoded))
+	}
+
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/volumes?%s", c.BaseURL, endpointID, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch volumes: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Volumes []rawVolume `json:"Volumes"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	users, err := c.volumeUsers(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := make([]backend.Volume, len(result.Volumes))
+	for i, raw := range result.Volumes {
+		volumes[i] = raw.toVolume(users)
+	}
+
+	sort.Slice(volumes, func(i, j int) bool { return volumes[i].Name < volumes[j].Name })
+	return volumes, nil
+}
+
+func (c *Client) CreateVolume(ctx context.Context, opts backend.VolumeCreateOptions) (*backend.Volume, error) {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/volumes/create", c.BaseURL, endpointID)
+
+	payload := map[string]interface{}{
+		"Name":       opts.Name,
+		"Driver":     opts.Driver,
+		"DriverOpts": opts.DriverOpts,
+		"Labels":     opts.Labels,
+	}
+
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusCreated, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	var raw rawVolume
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	volume := raw.toVolume(nil)
+	return &volume, nil
+}
+
+func (c *Client) InspectVolume(ctx context.Context, name string) (*backend.Volume, error) {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/volumes/%s", c.BaseURL, endpointID, url.PathEscape(name))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect volume: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("volume %s %w", name, backend.ErrNotFound)
+	}
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	var raw rawVolume
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	users, err := c.volumeUsers(ctx, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	volume := raw.toVolume(users)
+	return &volume, nil
+}
+
+func (c *Client) RemoveVolume(ctx context.Context, name string, force bool) error {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/volumes/%s?force=%t",
+		c.BaseURL, endpointID, url.PathEscape(name), force)
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusConflict {
+		return fmt.Errorf("volume %s is in use", name)
+	}
+
+	if err := checkResponse(resp, http.StatusNoContent, http.StatusOK); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) PruneVolumes(ctx context.Context, filters map[string][]string) (*backend.PruneReport, error) {
+	endpointID, err := c.resolveEndpoint(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	query := url.Values{}
+	if len(filters) > 0 {
+		encoded, err := json.Marshal(filters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode filters: %w", err)
+		}
+		query.Set("filters", string(encoded))
+	}
+
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/volumes/prune?%s", c.BaseURL, endpointID, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		VolumesDeleted []string `json:"VolumesDeleted"`
+		SpaceReclaimed uint64   `json:"SpaceReclaimed"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	report := &backend.PruneReport{
+		Deleted:        result.VolumesDeleted,
+		SpaceReclaimed: result.SpaceReclaimed,
+	}
+	if report.Deleted == nil {
+		report.Deleted = []string{}
+	}
+
+	return report, nil
+}
+
+// volumeUsers maps volume names to the names of containers that mount them
+func (c *Client) volumeUsers(ctx context.Context, endpointID int) (map[string][]string, error) {
+	url := fmt.Sprintf("%s/api/endpoints/%d/docker/containers/json?all=true", c.BaseURL, endpointID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch containers: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkResponse(resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+
+	var containers []struct {
+		Names  []string `json:"Names"`
+		Mounts []struct {
+			Type string `json:"Type"`
+			Name string `json:"Name"`
+		} `json:"Mounts"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&containers); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	users := make(map[string][]string)
+	for _, container := range containers {
+		name := "unknown"
+		if len(container.Names) > 0 {
+			name = strings.TrimPrefix(container.Names[0], "/")
+		}
+		for _, m := range container.Mounts {
+			if m.Type == "volume" && m.Name != "" {
+				users[m.Name] = append(users[m.Name], name)
+			}
+		}
+	}
+
+	for _, names := range users {
+		sort.Strings(names)
+	}
+
+	return users, nil
+}
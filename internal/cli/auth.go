@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend/portainer"
+	"github.com/Elias-Larsson/remdoc/internal/config"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// tokenExpiryWarning is how long before JWT expiry commands start warning
+const tokenExpiryWarning = 15 * time.Minute
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Inspect authentication state",
+}
+
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the auth mode and remaining token lifetime",
+	Long: `Show how the active context authenticates with Portainer and, for
+JWT logins, when the token expires.
+
+Examples:
+  remdoc auth status
+  remdoc auth status --context staging`,
+	Args: cobra.NoArgs,
+	RunE: runAuthStatus,
+}
+
+func init() {
+	authCmd.AddCommand(authStatusCmd)
+	rootCmd.AddCommand(authCmd)
+}
+
+func runAuthStatus(cmd *cobra.Command, args []string) error {
+	url, err := envURL()
+	if err != nil {
+		return err
+	}
+	if url != "" {
+		fmt.Printf("Context:   none (from %s)\n", config.URLEnv)
+		fmt.Printf("URL:       %s\n", url)
+		fmt.Printf("Auth mode: %s (from %s)\n", portainer.AuthModeAPIKey, config.APIKeyEnv)
+		fmt.Println("Expires:   never")
+		return nil
+	}
+
+	_, name, ctx, err := loadContext()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Context:   %s\n", name)
+	fmt.Printf("URL:       %s\n", ctx.PortainerURL)
+
+	if os.Getenv(config.APIKeyEnv) != "" {
+		fmt.Printf("Auth mode: %s (from %s)\n", portainer.AuthModeAPIKey, config.APIKeyEnv)
+		fmt.Println("Expires:   never")
+		return nil
+	}
+
+	switch {
+	case ctx.APIKey != "":
+		fmt.Printf("Auth mode: %s\n", portainer.AuthModeAPIKey)
+		fmt.Println("Expires:   never")
+	case ctx.JWT != "":
+		fmt.Printf("Auth mode: %s\n", portainer.AuthModeJWT)
+		if ctx.Username != "" {
+			fmt.Printf("Username:  %s\n", ctx.Username)
+		}
+
+		expiry, err := portainer.TokenExpiry(ctx.JWT)
+		if err != nil {
+			fmt.Printf("Expires:   unknown (%v)\n", err)
+			return nil
+		}
+
+		remaining := time.Until(expiry)
+		if remaining <= 0 {
+			fmt.Printf("Expires:   %s (expired %s ago)\n", expiry.Local().Format(time.RFC1123), formatDuration(-remaining))
+		} else {
+			fmt.Printf("Expires:   %s (in %s)\n", expiry.Local().Format(time.RFC1123), formatDuration(remaining))
+		}
+	default:
+		fmt.Println("Auth mode: none")
+		fmt.Printf("  Run 'remdoc login --context %s' to authenticate\n", name)
+	}
+
+	return nil
+}
+
+// warnTokenExpiry prints a warning to stderr when the JWT is about to expire
+func warnTokenExpiry(jwt string) {
+	expiry, err := portainer.TokenExpiry(jwt)
+	if err != nil {
+		return
+	}
+
+	remaining := time.Until(expiry)
+	if remaining > 0 && remaining < tokenExpiryWarning {
+		fmt.Fprintf(os.Stderr, "Warning: session expires in %s (run 'remdoc login' to renew)\n", formatDuration(remaining))
+	}
+}
+
+// reauthenticator returns a hook that prompts for the password and obtains a
+// fresh JWT, or nil when prompting is not possible (no TTY or unknown user)
+func reauthenticator(cfg *config.Config, name string, ctx *config.Context) func(context.Context) (string, error) {
+	if ctx.Username == "" || !term.IsTerminal(int(os.Stdin.Fd())) {
+		return nil
+	}
+
+	return func(context.Context) (string, error) {
+		fmt.Fprintf(os.Stderr, "Session for context %s has expired. Password for %s: ", name, ctx.Username)
+		passwordBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("failed to read password: %w", err)
+		}
+
+		jwt, err := getJWTFromPortainer(ctx.PortainerURL, ctx.Username, string(passwordBytes))
+		if err != nil {
+			return "", err
+		}
+
+		ctx.JWT = jwt
+		if err := config.Save(cfg); err != nil {
+			return "", fmt.Errorf("failed to save config: %w", err)
+		}
+
+		return jwt, nil
+	}
+}
+
+// refreshExpiredToken re-authenticates up front when the stored JWT has
+// already expired, so the prompt is not raced by request timeouts
+func refreshExpiredToken(client *portainer.Client) error {
+	if client.Reauthenticate == nil {
+		return nil
+	}
+
+	expiry, err := portainer.TokenExpiry(client.JWT)
+	if err != nil || time.Now().Before(expiry) {
+		return nil
+	}
+
+	jwt, err := client.Reauthenticate(context.Background())
+	if err != nil {
+		return fmt.Errorf("re-authentication failed: %w", err)
+	}
+	client.JWT = jwt
+	return nil
+}
+
+// formatDuration renders a duration rounded to minutes (or seconds when short)
+func formatDuration(d time.Duration) string {
+	if d < time.Minute {
+		return d.Round(time.Second).String()
+	}
+	s := d.Round(time.Minute).String()
+	return strings.TrimSuffix(s, "0s")
+}
@@ -5,15 +5,32 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+	"github.com/Elias-Larsson/remdoc/internal/compose"
 	"github.com/spf13/cobra"
 )
 
 var (
-	composeFile string
-	composeName string
+	composeFiles    []string
+	composeName     string
+	composeProfiles []string
+	composePrune    bool
+	composePull     bool
+	composeEnv      []string
+	composeEnvFiles []string
+	composeValidate bool
+	configQuiet     bool
+
+	composeGitURL       string
+	composeGitRef       string
+	composeGitPath      string
+	composeAutoInterval string
+	composeAutoWebhook  bool
 )
 
 var composeCmd = &cobra.Command{
@@ -21,46 +38,486 @@ var composeCmd = &cobra.Command{
 	Short: "Deploy a Docker Compose file as a stack",
 	Long: `Deploy a local docker-compose file to the remote server via Portainer.
 
+If a stack with the same name already exists it is updated in place.
+
+Several -f files are merged locally with Docker Compose's override rules
+before deploying, and --profile enables services assigned to profiles.
+
+With --git-url, Portainer deploys the compose file straight from a Git
+repository instead, and can keep the stack up to date by polling the
+repository or through a webhook. Redeploy it later with 'remdoc stack pull'.
+
 Examples:
   remdoc compose --file ./docker-compose.yml --name my-stack
-  remdoc compose -f ./compose.yaml -n my-stack`,
+  remdoc compose -f ./compose.yaml -n my-stack
+
+  # Merge per-environment overrides and enable optional services
+  remdoc compose -f compose.yaml -f compose.prod.yaml -n my-stack --profile monitoring
+
+  # Provide values for ${VAR} interpolation in the compose file
+  remdoc compose -f ./compose.yaml -n my-stack --env-file .env.production -e TAG=1.4.2
+
+  # Update an existing stack, pulling new images and removing dropped services
+  remdoc compose -f ./compose.yaml -n my-stack --pull --prune
+
+  # Wait until every container of the stack is running and healthy
+  remdoc compose -f ./compose.yaml -n my-stack --wait
+
+  # Check the file locally before sending it
+  remdoc compose -f ./compose.yaml -n my-stack --validate
+
+  # Deploy from Git and redeploy when the branch changes
+  remdoc compose --git-url https://github.com/acme/shop.git --ref main \
+    --path deploy/compose.yml -n shop --auto-update-interval 5m`,
 	RunE: runCompose,
 }
 
+var composeConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Validate a compose file and print it with variables resolved",
+	Long: `Parse and validate compose files locally, merge them, resolve ${VAR}
+interpolation against --env and --env-file, and print the effective file.
+Nothing is sent to the server.
+
+Examples:
+  remdoc compose config -f ./compose.yaml --env-file .env.production
+  remdoc compose config -f ./compose.yaml -f ./compose.prod.yaml --profile debug
+  remdoc compose config -f ./compose.yaml -q
+  remdoc compose config -f ./compose.yaml -o json`,
+	Args: cobra.NoArgs,
+	RunE: runComposeConfig,
+}
+
 func init() {
-	composeCmd.Flags().StringVarP(&composeFile, "file", "f", "", "Path to docker-compose file (required)")
-	composeCmd.Flags().StringVarP(&composeName, "name", "n", "", "Stack name (optional; defaults to file name)")
-	composeCmd.MarkFlagRequired("file")
+	composeCmd.PersistentFlags().StringArrayVarP(&composeFiles, "file", "f", []string{}, "Path to docker-compose file (required; repeat to merge override files)")
+	composeCmd.PersistentFlags().StringVarP(&composeName, "name", "n", "", "Stack name (optional; defaults to the first file name)")
+	composeCmd.PersistentFlags().StringArrayVar(&composeProfiles, "profile", []string{}, "Enable services in a profile (can be specified multiple times; defaults to $COMPOSE_PROFILES)")
+	composeCmd.PersistentFlags().StringArrayVarP(&composeEnv, "env", "e", []string{}, "Stack environment variable for ${VAR} interpolation (KEY=value, can be specified multiple times)")
+	composeCmd.PersistentFlags().StringArrayVar(&composeEnvFiles, "env-file", []string{}, "Read stack environment variables from a .env file (can be specified multiple times)")
+
+	composeCmd.Flags().BoolVar(&composePrune, "prune", false, "When updating, remove services that are no longer in the compose file")
+	composeCmd.Flags().BoolVar(&composePull, "pull", false, "When updating, pull the latest images before redeploying")
+	composeCmd.Flags().BoolVar(&composeValidate, "validate", false, "Validate the compose file locally before deploying (use 'compose config' to print the effective file)")
+
+	composeCmd.Flags().StringVar(&composeGitURL, "git-url", "", "Deploy from a Git repository instead of local files")
+	composeCmd.Flags().StringVar(&composeGitRef, "ref", "", "Git branch or reference, e.g. main or refs/tags/v1.2 (default: the repository's default branch)")
+	composeCmd.Flags().StringVar(&composeGitPath, "path", "docker-compose.yml", "Path of the compose file inside the repository")
+	composeCmd.Flags().StringVar(&composeAutoInterval, "auto-update-interval", "", "Poll the repository and redeploy on changes at this interval (e.g. 5m)")
+	composeCmd.Flags().BoolVar(&composeAutoWebhook, "auto-update-webhook", false, "Create a webhook URL that triggers a redeploy from the repository")
+	addGitAuthFlags(composeCmd)
+	addWaitFlags(composeCmd)
+	composeCmd.MarkFlagsMutuallyExclusive("git-url", "file")
+	composeCmd.MarkFlagsMutuallyExclusive("git-url", "profile")
+	composeCmd.MarkFlagsMutuallyExclusive("git-url", "validate")
+
+	composeConfigCmd.Flags().BoolVarP(&configQuiet, "quiet", "q", false, "Only validate the file, don't print it")
+
+	composeCmd.AddCommand(composeConfigCmd)
 	rootCmd.AddCommand(composeCmd)
 }
 
 func runCompose(cmd *cobra.Command, args []string) error {
+	if composeGitURL != "" {
+		return runComposeGit(cmd)
+	}
+	for _, flag := range []string{"ref", "path", "auto-update-interval", "auto-update-webhook", "git-username", "git-password"} {
+		if cmd.Flags().Changed(flag) {
+			return fmt.Errorf("--%s requires --git-url", flag)
+		}
+	}
+	if len(composeFiles) == 0 {
+		return fmt.Errorf("--file is required (or --git-url to deploy from a repository)")
+	}
+
+	project, content, err := loadComposeFiles()
+	if err != nil {
+		return err
+	}
+
+	name := composeStackName()
+
+	env, err := composeEnvVars()
+	if err != nil {
+		return err
+	}
+
+	// The file's structure doesn't depend on the environment, so check it
+	// before contacting the server. Variables can only be resolved up front
+	// when env flags are given; otherwise an existing stack keeps its stored
+	// environment.
+	if composeValidate {
+		if err := project.Validate(); err != nil {
+			return fmt.Errorf("invalid compose file %s: %w", strings.Join(composeFiles, ", "), err)
+		}
+		if composeEnvGiven() {
+			if _, err := checkCompose(project, env); err != nil {
+				return err
+			}
+		}
+	}
+
 	client, err := getClient()
 	if err != nil {
 		return err
 	}
 
-	content, err := os.ReadFile(composeFile)
+	ctx, cancel := context.WithTimeout(context.Background(), stackTimeout)
+	defer cancel()
+
+	stacks, err := client.ListStacks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch stacks: %w", err)
+	}
+
+	var existing *backend.Stack
+	for i := range stacks {
+		if stacks[i].Name == name {
+			existing = &stacks[i]
+			break
+		}
+	}
+
+	if composeValidate && !composeEnvGiven() {
+		stackEnv := env
+		if existing != nil {
+			stackEnv = existing.Env
+		}
+		if _, err := checkCompose(project, stackEnv); err != nil {
+			return err
+		}
+	}
+
+	// Redeploying under an existing name updates that stack in place
+	if existing != nil {
+		progressf("Updating existing compose stack %s from %s...\n", name, strings.Join(composeFiles, ", "))
+
+		opts := backend.StackUpdateOptions{
+			Content: string(content),
+			Prune:   composePrune,
+			Pull:    composePull,
+		}
+
+		// Without env flags the stack keeps its current environment
+		if composeEnvGiven() {
+			opts.Env = nonNil(env)
+		}
+		if err := client.UpdateStack(ctx, strconv.Itoa(existing.ID), opts); err != nil {
+			return fmt.Errorf("compose update failed: %w", err)
+		}
+		if err := waitForCompose(client, name); err != nil {
+			return err
+		}
+
+		result := stackResult{Action: "update", Stack: name, ID: existing.ID, Status: "updated"}
+		return printResult(result, name, func() {
+			fmt.Printf("✓ Stack updated successfully (ID: %d)\n", existing.ID)
+		})
+	}
+
+	progressf("Deploying compose stack %s from %s...\n", name, strings.Join(composeFiles, ", "))
+
+	stackID, err := client.DeployComposeStack(ctx, name, string(content), env)
+	if err != nil {
+		return fmt.Errorf("compose deployment failed: %w", err)
+	}
+	if err := waitForCompose(client, name); err != nil {
+		return err
+	}
+
+	result := stackResult{Action: "deploy", Stack: name, ID: stackID, Status: "deployed"}
+	return printResult(result, name, func() {
+		fmt.Printf("✓ Stack deployed successfully (ID: %d)\n", stackID)
+	})
+}
+
+// stackResult is the structured result of a stack action
+type stackResult struct {
+	Action  string `json:"action" yaml:"action"`
+	Stack   string `json:"stack" yaml:"stack"`
+	ID      int    `json:"id" yaml:"id"`
+	Status  string `json:"status" yaml:"status"`
+	Webhook string `json:"webhook,omitempty" yaml:"webhook,omitempty"` // Redeploy URL for Git-backed stacks
+}
+
+// runComposeGit deploys a stack from a Git repository, or pulls the
+// repository again if the stack already exists
+func runComposeGit(cmd *cobra.Command) error {
+	username, password, err := gitCredentials()
 	if err != nil {
-		return fmt.Errorf("failed to read compose file: %w", err)
+		return err
+	}
+
+	var autoUpdate *backend.StackAutoUpdate
+	if composeAutoInterval != "" || composeAutoWebhook {
+		autoUpdate = &backend.StackAutoUpdate{}
+	}
+	if composeAutoInterval != "" {
+		if _, err := time.ParseDuration(composeAutoInterval); err != nil {
+			return fmt.Errorf("invalid --auto-update-interval %q (use a duration such as 5m or 1h)", composeAutoInterval)
+		}
+		autoUpdate.Interval = composeAutoInterval
+	}
+	if composeAutoWebhook {
+		if autoUpdate.Webhook, err = newWebhookID(); err != nil {
+			return err
+		}
 	}
 
 	name := strings.TrimSpace(composeName)
 	if name == "" {
-		base := filepath.Base(composeFile)
-		name = strings.TrimSuffix(base, filepath.Ext(base))
+		name = repositoryName(composeGitURL)
+	}
+
+	env, err := composeEnvVars()
+	if err != nil {
+		return err
 	}
 
-	fmt.Printf("Deploying compose stack %s from %s...\n", name, composeFile)
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), stackTimeout)
 	defer cancel()
 
-	stackID, err := client.DeployComposeStack(ctx, name, string(content))
+	stacks, err := client.ListStacks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch stacks: %w", err)
+	}
+
+	for _, existing := range stacks {
+		if existing.Name != name {
+			continue
+		}
+
+		if existing.Git == nil {
+			return fmt.Errorf("stack %s already exists and is not deployed from Git", name)
+		}
+		if existing.Git.URL != composeGitURL {
+			return fmt.Errorf("stack %s is deployed from %s, not %s", name, existing.Git.URL, composeGitURL)
+		}
+		if autoUpdate != nil {
+			fmt.Fprintln(os.Stderr, "Warning: auto-update settings only apply when a stack is created; ignoring them")
+		}
+
+		progressf("Pulling existing stack %s from %s...\n", name, composeGitURL)
+
+		opts := backend.StackPullOptions{
+			Ref:      composeGitRef,
+			Username: username,
+			Password: password,
+			Prune:    composePrune,
+			Pull:     composePull,
+		}
+		if composeEnvGiven() {
+			opts.Env = nonNil(env)
+		}
+		if err := client.PullStack(ctx, strconv.Itoa(existing.ID), opts); err != nil {
+			return fmt.Errorf("compose update failed: %w", err)
+		}
+		if err := waitForCompose(client, name); err != nil {
+			return err
+		}
+
+		result := stackResult{Action: "pull", Stack: name, ID: existing.ID, Status: "updated"}
+		return printResult(result, name, func() {
+			fmt.Printf("✓ Stack updated from Git successfully (ID: %d)\n", existing.ID)
+		})
+	}
+
+	progressf("Deploying compose stack %s from %s...\n", name, composeGitURL)
+
+	stackID, err := client.DeployGitStack(ctx, backend.GitStackOptions{
+		Name:       name,
+		URL:        composeGitURL,
+		Ref:        composeGitRef,
+		Path:       composeGitPath,
+		Username:   username,
+		Password:   password,
+		Env:        env,
+		AutoUpdate: autoUpdate,
+	})
 	if err != nil {
 		return fmt.Errorf("compose deployment failed: %w", err)
 	}
+	if err := waitForCompose(client, name); err != nil {
+		return err
+	}
+
+	result := stackResult{Action: "deploy", Stack: name, ID: stackID, Status: "deployed"}
+	if composeAutoWebhook {
+		result.Webhook = client.StackWebhookURL(autoUpdate.Webhook)
+	}
+
+	return printResult(result, name, func() {
+		fmt.Printf("✓ Stack deployed from Git successfully (ID: %d)\n", stackID)
+		if result.Webhook != "" {
+			fmt.Printf("  Webhook: %s\n", result.Webhook)
+		}
+	})
+}
+
+// waitForCompose waits for the stack's containers when --wait is set
+func waitForCompose(client backend.Backend, stack string) error {
+	if !waitReady {
+		return nil
+	}
+
+	ctx, cancel := waitContext()
+	defer cancel()
+
+	return waitForStack(ctx, client, stack)
+}
+
+func runComposeConfig(cmd *cobra.Command, args []string) error {
+	if len(composeFiles) == 0 {
+		return fmt.Errorf("--file is required")
+	}
+
+	merged, _, err := loadComposeFiles()
+	if err != nil {
+		return err
+	}
+
+	env, err := composeEnvVars()
+	if err != nil {
+		return err
+	}
+
+	project, err := checkCompose(merged, env)
+	if err != nil {
+		return err
+	}
+
+	if configQuiet {
+		return nil
+	}
+
+	if outputFlag == outputJSON || outputFlag == outputYAML {
+		effective, err := project.Map()
+		if err != nil {
+			return err
+		}
+		return printResult(effective, composeStackName(), nil)
+	}
+
+	if outputFlag == outputName {
+		fmt.Println(composeStackName())
+		return nil
+	}
+
+	effective, err := project.Bytes()
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stdout.Write(effective)
+	return err
+}
+
+// composeStackName returns --name, or the first compose file name without its extension
+func composeStackName() string {
+	name := strings.TrimSpace(composeName)
+	if name == "" {
+		base := filepath.Base(composeFiles[0])
+		name = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+	return name
+}
+
+// composeEnvGiven reports whether any env flags were passed
+func composeEnvGiven() bool {
+	return len(composeEnv) > 0 || len(composeEnvFiles) > 0
+}
+
+// composeEnvVars combines --env-file and --env. --env overrides --env-file,
+// and later files override earlier ones.
+func composeEnvVars() ([]backend.EnvVar, error) {
+	envEntries, err := readEnvFiles(composeEnvFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := parseEnvList(append(envEntries, composeEnv...))
+	if err != nil {
+		return nil, fmt.Errorf("invalid environment variable: %w", err)
+	}
+
+	return env, nil
+}
+
+// loadComposeFiles reads and merges the -f files and applies the active
+// profiles. It returns the merged project, which is not yet interpolated, and
+// the content to deploy: a single file without profiles is sent as written,
+// anything else as the merged result.
+func loadComposeFiles() (*compose.Project, []byte, error) {
+	var projects []*compose.Project
+	var content []byte
+
+	for _, path := range composeFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read compose file: %w", err)
+		}
+
+		project, err := compose.Parse(data)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid compose file %s: %w", path, err)
+		}
+		projects = append(projects, project)
+		content = data
+	}
+
+	merged := compose.Merge(projects...)
+	declared := merged.Profiles()
+
+	profiles := composeProfiles
+	if len(profiles) == 0 && os.Getenv("COMPOSE_PROFILES") != "" {
+		profiles = strings.Split(os.Getenv("COMPOSE_PROFILES"), ",")
+	}
+	for _, profile := range profiles {
+		if profile != "*" && !slices.Contains(declared, profile) {
+			fmt.Fprintf(os.Stderr, "Warning: no service uses profile %s\n", profile)
+		}
+	}
+
+	if err := merged.ApplyProfiles(profiles); err != nil {
+		return nil, nil, err
+	}
+
+	if len(projects) > 1 || len(declared) > 0 {
+		var err error
+		if content, err = merged.Bytes(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return merged, content, nil
+}
+
+// checkCompose interpolates a copy of the project against env and validates
+// it, printing warnings such as unset variables to stderr
+func checkCompose(project *compose.Project, env []backend.EnvVar) (*compose.Project, error) {
+	values := make(map[string]string, len(env))
+	for _, v := range env {
+		values[v.Name] = v.Value
+	}
+
+	files := strings.Join(composeFiles, ", ")
+
+	effective := project.Clone()
+	if err := effective.Interpolate(values); err != nil {
+		return nil, fmt.Errorf("invalid compose file %s: %w", files, err)
+	}
+
+	for _, warning := range effective.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	if err := effective.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid compose file %s: %w", files, err)
+	}
 
-	fmt.Printf("✓ Stack deployed successfully (ID: %d)\n", stackID)
-	return nil
+	return effective, nil
 }
@@ -0,0 +1,271 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend/portainer"
+	"github.com/Elias-Larsson/remdoc/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	contextAddURL      string
+	contextAddEndpoint string
+)
+
+var contextCmd = &cobra.Command{
+	Use:   "context",
+	Short: "Manage named Portainer contexts",
+	Long: `Manage named contexts, each holding the URL, credentials and default
+endpoint of a Portainer instance.
+
+The active context is chosen by the --context flag, then the REMDOC_CONTEXT
+environment variable, then the current context saved in the config file.
+
+Examples:
+  remdoc context list
+  remdoc context add staging --url https://portainer.staging.example.com
+  remdoc login -u admin --context staging
+  remdoc context use staging
+  remdoc status --context production`,
+}
+
+var contextListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List all contexts",
+	Args:    cobra.NoArgs,
+	RunE:    runContextList,
+}
+
+var contextUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the current context",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runContextUse,
+}
+
+var contextAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a context without credentials",
+	Long: `Add a new context pointing at a Portainer instance.
+
+The context has no credentials until you run 'remdoc login --context <name>'.
+
+Examples:
+  remdoc context add staging --url https://portainer.staging.example.com
+  remdoc context add prod --url https://portainer.example.com --endpoint 2`,
+	Args: cobra.ExactArgs(1),
+	RunE: runContextAdd,
+}
+
+var contextRemoveCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a context",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runContextRemove,
+}
+
+var contextRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename a context",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runContextRename,
+}
+
+var contextCurrentCmd = &cobra.Command{
+	Use:   "current",
+	Short: "Print the active context",
+	Args:  cobra.NoArgs,
+	RunE:  runContextCurrent,
+}
+
+func init() {
+	contextAddCmd.Flags().StringVar(&contextAddURL, "url", "", "Portainer URL (required)")
+	contextAddCmd.Flags().StringVar(&contextAddEndpoint, "endpoint", "", "Default endpoint ID or name")
+	contextAddCmd.MarkFlagRequired("url")
+
+	contextCmd.AddCommand(contextListCmd, contextUseCmd, contextAddCmd, contextRemoveCmd, contextRenameCmd, contextCurrentCmd)
+	rootCmd.AddCommand(contextCmd)
+}
+
+func runContextList(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadOrNew()
+	if err != nil {
+		return err
+	}
+
+	if len(cfg.Contexts) == 0 {
+		fmt.Println("No contexts found.")
+		return nil
+	}
+
+	active, _, _ := cfg.Active(contextFlag)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "CURRENT\tNAME\tURL\tENDPOINT\tAUTH")
+
+	for _, name := range cfg.ContextNames() {
+		c := cfg.Contexts[name]
+
+		marker := ""
+		if name == active {
+			marker = "*"
+		}
+
+		endpoint := c.Endpoint
+		if endpoint == "" {
+			endpoint = "(first)"
+		}
+
+		auth := "none"
+		switch {
+		case c.APIKey != "":
+			auth = portainer.AuthModeAPIKey
+		case c.JWT != "":
+			auth = portainer.AuthModeJWT
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", marker, name, c.PortainerURL, endpoint, auth)
+	}
+
+	w.Flush()
+	return nil
+}
+
+func runContextUse(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := cfg.Contexts[name]; !ok {
+		return fmt.Errorf("context %q not found", name)
+	}
+
+	cfg.CurrentContext = name
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("✓ Switched to context %s\n", name)
+	return nil
+}
+
+func runContextAdd(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+	if name == "" {
+		return fmt.Errorf("context name cannot be empty")
+	}
+
+	url := strings.TrimSpace(contextAddURL)
+	if url == "" {
+		return fmt.Errorf("URL cannot be empty")
+	}
+
+	cfg, err := config.LoadOrNew()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := cfg.Contexts[name]; ok {
+		return fmt.Errorf("context %q already exists", name)
+	}
+
+	cfg.Contexts[name] = &config.Context{
+		PortainerURL: url,
+		Endpoint:     contextAddEndpoint,
+	}
+	if cfg.CurrentContext == "" {
+		cfg.CurrentContext = name
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("✓ Context %s added (run 'remdoc login --context %s' to authenticate)\n", name, name)
+	return nil
+}
+
+func runContextRemove(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := cfg.Contexts[name]; !ok {
+		return fmt.Errorf("context %q not found", name)
+	}
+
+	delete(cfg.Contexts, name)
+	if cfg.CurrentContext == name {
+		cfg.CurrentContext = ""
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("✓ Context %s removed\n", name)
+	if cfg.CurrentContext == "" && len(cfg.Contexts) > 0 {
+		fmt.Println("  No current context set; run 'remdoc context use <name>' to select one")
+	}
+	return nil
+}
+
+func runContextRename(cmd *cobra.Command, args []string) error {
+	oldName, newName := args[0], strings.TrimSpace(args[1])
+	if newName == "" {
+		return fmt.Errorf("context name cannot be empty")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	c, ok := cfg.Contexts[oldName]
+	if !ok {
+		return fmt.Errorf("context %q not found", oldName)
+	}
+	if _, exists := cfg.Contexts[newName]; exists {
+		return fmt.Errorf("context %q already exists", newName)
+	}
+
+	delete(cfg.Contexts, oldName)
+	cfg.Contexts[newName] = c
+	if cfg.CurrentContext == oldName {
+		cfg.CurrentContext = newName
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("✓ Context %s renamed to %s\n", oldName, newName)
+	return nil
+}
+
+func runContextCurrent(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	name, _, err := cfg.Active(contextFlag)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(name)
+	return nil
+}
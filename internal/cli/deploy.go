@@ -3,6 +3,10 @@ package cli
 import (
 	"context"
 	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,37 +19,148 @@ var (
 	deployName       string
 	deployPorts      []string
 	deployEnv        []string
+	deployEnvFiles   []string
 	deployRestart    string
 	deployAutoRemove bool
+	deployVolumes    []string
+	deployMounts     []string
+	deployNetwork    string
+	deployAliases    []string
+	deployIP         string
+	deployPull       string
+
+	deployHealthCmd         string
+	deployHealthInterval    time.Duration
+	deployHealthTimeout     time.Duration
+	deployHealthRetries     int
+	deployHealthStartPeriod time.Duration
+	deployNoHealthcheck     bool
+
+	deployMemory      string
+	deployCPUs        float64
+	deployPidsLimit   int64
+	deployUser        string
+	deployWorkdir     string
+	deployEntrypoint  string
+	deployHostname    string
+	deployCapAdd      []string
+	deployCapDrop     []string
+	deployReadOnly    bool
+	deployPrivileged  bool
+	deploySecurityOpt []string
+	deployUlimits     []string
+	deployLogDriver   string
+	deployLogOpts     []string
 )
 
 var deployCmd = &cobra.Command{
-	Use:   "deploy",
+	Use:   "deploy [flags] [--] [COMMAND] [ARG...]",
 	Short: "Deploy a new container to the remote server",
 	Long: `Create and start a Docker container on the remote server via Portainer.
 
+Arguments after the flags replace the image's command, as with docker run.
+
 Examples:
   # Deploy nginx with port mapping
   remdoc deploy --image nginx:latest --name my-nginx --port 8080:80
 
+  # Bind to localhost only, publish UDP, or publish a range
+  remdoc deploy --image nginx:latest --port 127.0.0.1:8080:80
+  remdoc deploy --image coredns/coredns --port 53:53/udp
+  remdoc deploy --image myapp --port 8000-8010:8000-8010
+
+  # Publish a container port on a random host port
+  remdoc deploy --image nginx:latest --port 80
+
   # Deploy with environment variables
   remdoc deploy --image postgres:14 --name my-db --port 5432:5432 \
     --env POSTGRES_PASSWORD=secret --env POSTGRES_DB=myapp
 
+  # Load environment variables from a file
+  remdoc deploy --image myapp --name api --env-file ./api.env
+
+  # Persist data in a named volume and mount config read-only
+  remdoc deploy --image postgres:14 --name my-db \
+    -v pgdata:/var/lib/postgresql/data -v /srv/pg/conf:/etc/postgresql:ro
+
+  # Attach a tmpfs or a bind mount with --mount
+  remdoc deploy --image myapp --mount type=tmpfs,target=/cache,tmpfs-size=64m \
+    --mount type=bind,source=/srv/app,target=/app,readonly
+
+  # Join a user-defined network so other containers can reach it by name
+  remdoc deploy --image postgres:14 --name db --network backend \
+    --network-alias database --ip 172.28.0.10
+
+  # Always pull the latest version of the image before deploying
+  remdoc deploy --image nginx:latest --name my-nginx --pull always
+
+  # Define a healthcheck so the container reports its health
+  remdoc deploy --image myapp --name api \
+    --health-cmd "curl -fs http://localhost:8080/health || exit 1" \
+    --health-interval 10s --health-retries 3 --health-start-period 30s
+
+  # Limit resources and harden the container
+  remdoc deploy --image myapp --name api --memory 512m --cpus 1.5 --pids-limit 200 \
+    --user 1000:1000 --read-only --cap-drop ALL --cap-add NET_BIND_SERVICE \
+    --security-opt no-new-privileges --ulimit nofile=4096:8192
+
+  # Override the entrypoint and command, and rotate logs
+  remdoc deploy --image alpine --name job --restart no --entrypoint /bin/sh \
+    --log-driver json-file --log-opt max-size=10m --log-opt max-file=3 -- -c "echo hello"
+
   # Deploy with restart policy
   remdoc deploy --image redis:alpine --name my-redis --port 6379:6379 \
-    --restart unless-stopped`,
+    --restart unless-stopped
+
+  # Wait until the container is healthy, failing with its logs if it crashes
+  remdoc deploy --image myapp --name api --wait --wait-timeout 3m`,
 	RunE: runDeploy,
 }
 
 func init() {
 	deployCmd.Flags().StringVar(&deployImage, "image", "", "Docker image to deploy (required)")
 	deployCmd.Flags().StringVar(&deployName, "name", "", "Container name (optional, Docker will generate if not provided)")
-	deployCmd.Flags().StringSliceVarP(&deployPorts, "port", "p", []string{}, "Port mappings (e.g., 8080:80, can be specified multiple times)")
+	deployCmd.Flags().StringSliceVarP(&deployPorts, "port", "p", []string{}, "Port mappings ([HOST_IP:][HOST_PORT:]CONTAINER_PORT[/PROTOCOL], ranges allowed; can be specified multiple times)")
 	deployCmd.Flags().StringSliceVarP(&deployEnv, "env", "e", []string{}, "Environment variables (e.g., KEY=value, can be specified multiple times)")
+	deployCmd.Flags().StringArrayVar(&deployEnvFiles, "env-file", []string{}, "Read environment variables from a .env file (can be specified multiple times)")
 	deployCmd.Flags().StringVar(&deployRestart, "restart", "unless-stopped", "Restart policy (no, always, unless-stopped, on-failure)")
 	deployCmd.Flags().BoolVar(&deployAutoRemove, "rm", false, "Automatically remove the container when it stops")
+	deployCmd.Flags().StringArrayVarP(&deployVolumes, "volume", "v", []string{}, "Bind mount a volume or host path (e.g., data:/data, /srv/conf:/etc/app:ro; can be specified multiple times)")
+	deployCmd.Flags().StringArrayVar(&deployMounts, "mount", []string{}, "Attach a mount (e.g., type=tmpfs,target=/cache,tmpfs-size=64m; can be specified multiple times)")
+
+	deployCmd.Flags().StringVar(&deployNetwork, "network", "", "Connect the container to a network (name, or host/none)")
+	deployCmd.Flags().StringArrayVar(&deployAliases, "network-alias", []string{}, "Add a DNS alias for the container on --network (can be specified multiple times)")
+	deployCmd.Flags().StringVar(&deployIP, "ip", "", "Static IPv4 address on --network (requires a network with a configured subnet)")
 
+	deployCmd.Flags().StringVar(&deployHealthCmd, "health-cmd", "", "Command to run to check health (run with /bin/sh -c)")
+	deployCmd.Flags().DurationVar(&deployHealthInterval, "health-interval", 0, "Time between health checks (e.g., 30s; default: image's or 30s)")
+	deployCmd.Flags().DurationVar(&deployHealthTimeout, "health-timeout", 0, "Maximum time a health check may run (e.g., 5s; default: image's or 30s)")
+	deployCmd.Flags().IntVar(&deployHealthRetries, "health-retries", 0, "Consecutive failures needed to report unhealthy (default: image's or 3)")
+	deployCmd.Flags().DurationVar(&deployHealthStartPeriod, "health-start-period", 0, "Grace period for the container to start before failures count (e.g., 1m)")
+	deployCmd.Flags().BoolVar(&deployNoHealthcheck, "no-healthcheck", false, "Disable any HEALTHCHECK defined by the image")
+
+	deployCmd.Flags().StringVarP(&deployMemory, "memory", "m", "", "Memory limit (e.g., 512m, 1.5g)")
+	deployCmd.Flags().Float64Var(&deployCPUs, "cpus", 0, "Number of CPUs the container may use (e.g., 0.5, 2)")
+	deployCmd.Flags().Int64Var(&deployPidsLimit, "pids-limit", 0, "Maximum number of processes (-1 for unlimited)")
+	deployCmd.Flags().StringVarP(&deployUser, "user", "u", "", "User to run as (NAME|UID[:GROUP|GID])")
+	deployCmd.Flags().StringVarP(&deployWorkdir, "workdir", "w", "", "Working directory inside the container")
+	deployCmd.Flags().StringVar(&deployEntrypoint, "entrypoint", "", "Override the image's entrypoint (\"\" clears it)")
+	deployCmd.Flags().StringVar(&deployHostname, "hostname", "", "Container hostname")
+	deployCmd.Flags().StringSliceVar(&deployCapAdd, "cap-add", []string{}, "Add Linux capabilities (e.g., NET_ADMIN; can be specified multiple times)")
+	deployCmd.Flags().StringSliceVar(&deployCapDrop, "cap-drop", []string{}, "Drop Linux capabilities (e.g., ALL; can be specified multiple times)")
+	deployCmd.Flags().BoolVar(&deployReadOnly, "read-only", false, "Mount the container's root filesystem as read only")
+	deployCmd.Flags().BoolVar(&deployPrivileged, "privileged", false, "Give the container extended privileges")
+	deployCmd.Flags().StringArrayVar(&deploySecurityOpt, "security-opt", []string{}, "Security option (e.g., no-new-privileges, seccomp=./profile.json; can be specified multiple times)")
+	deployCmd.Flags().StringArrayVar(&deployUlimits, "ulimit", []string{}, "Ulimit (NAME=SOFT[:HARD], e.g., nofile=1024:2048; can be specified multiple times)")
+	deployCmd.Flags().StringVar(&deployLogDriver, "log-driver", "", "Logging driver (e.g., json-file, local, syslog)")
+	deployCmd.Flags().StringArrayVar(&deployLogOpts, "log-opt", []string{}, "Logging driver option (KEY=VALUE, can be specified multiple times)")
+
+	deployCmd.Flags().StringVar(&deployPull, "pull", pullMissing, "Pull the image before deploying (always, missing, never)")
+	addRegistryFlags(deployCmd)
+	addWaitFlags(deployCmd)
+
+	// Arguments after the flags are the container's command
+	deployCmd.Flags().SetInterspersed(false)
 	deployCmd.MarkFlagRequired("image")
 	rootCmd.AddCommand(deployCmd)
 }
@@ -61,21 +176,121 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid port mapping: %w", err)
 	}
 
-	envMap, err := parseEnv(deployEnv)
+	// --env overrides --env-file, and later files override earlier ones
+	envEntries, err := readEnvFiles(deployEnvFiles)
+	if err != nil {
+		return err
+	}
+
+	envMap, err := parseEnv(append(envEntries, deployEnv...))
 	if err != nil {
 		return fmt.Errorf("invalid environment variable: %w", err)
 	}
 
+	binds, volumeMounts, err := parseVolumes(deployVolumes)
+	if err != nil {
+		return fmt.Errorf("invalid volume: %w", err)
+	}
+
+	mounts, err := parseMounts(deployMounts)
+	if err != nil {
+		return fmt.Errorf("invalid mount: %w", err)
+	}
+
+	if err := validateNetworkFlags(deployNetwork, deployAliases, deployIP); err != nil {
+		return err
+	}
+
+	healthcheck, err := parseHealthcheck(cmd)
+	if err != nil {
+		return err
+	}
+
+	var memory int64
+	if deployMemory != "" {
+		memory, err = parseByteSize(deployMemory)
+		if err != nil {
+			return fmt.Errorf("invalid --memory: %w", err)
+		}
+	}
+
+	nanoCPUs, err := parseCPUs(deployCPUs)
+	if err != nil {
+		return err
+	}
+
+	ulimits, err := parseUlimits(deployUlimits)
+	if err != nil {
+		return fmt.Errorf("invalid ulimit: %w", err)
+	}
+
+	securityOpts, err := parseSecurityOpts(deploySecurityOpt)
+	if err != nil {
+		return err
+	}
+
+	logOpts, err := parseKeyValues(deployLogOpts)
+	if err != nil {
+		return fmt.Errorf("invalid log option: %w", err)
+	}
+
+	// Like docker, an empty --entrypoint clears the image's entrypoint
+	var entrypoint []string
+	if cmd.Flags().Changed("entrypoint") {
+		entrypoint = []string{deployEntrypoint}
+	}
+
+	var command []string
+	if len(args) > 0 {
+		command = args
+	}
+
+	switch deployPull {
+	case pullAlways, pullMissing, pullNever:
+	default:
+		return fmt.Errorf("unsupported pull policy %q (use always, missing or never)", deployPull)
+	}
+
 	opts := backend.DeployOptions{
-		Name:       deployName,
-		Image:      deployImage,
-		Ports:      portMappings,
-		Env:        envMap,
-		Restart:    deployRestart,
-		AutoRemove: deployAutoRemove,
+		Name:        deployName,
+		Image:       deployImage,
+		Ports:       portMappings,
+		Env:         envMap,
+		Restart:     deployRestart,
+		AutoRemove:  deployAutoRemove,
+		Binds:       binds,
+		Mounts:      append(volumeMounts, mounts...),
+		Network:     deployNetwork,
+		Aliases:     deployAliases,
+		IPAddress:   deployIP,
+		Healthcheck: healthcheck,
+		Hostname:    deployHostname,
+		User:        deployUser,
+		WorkingDir:  deployWorkdir,
+		Entrypoint:  entrypoint,
+		Cmd:         command,
+		Memory:      memory,
+		NanoCPUs:    nanoCPUs,
+		PidsLimit:   deployPidsLimit,
+		CapAdd:      normalizeCapabilities(deployCapAdd),
+		CapDrop:     normalizeCapabilities(deployCapDrop),
+		ReadOnly:    deployReadOnly,
+		Privileged:  deployPrivileged,
+		SecurityOpt: securityOpts,
+		Ulimits:     ulimits,
+		LogDriver:   deployLogDriver,
+		LogOptions:  logOpts,
 	}
 
-	fmt.Printf("Deploying container from image %s...\n", deployImage)
+	// Pulls can take minutes for large images; only an interrupt stops them
+	pullCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := ensureImage(pullCtx, client, deployImage, deployPull); err != nil {
+		return err
+	}
+
+	progressf("Deploying container from image %s...\n", deployImage)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -85,34 +300,267 @@ func runDeploy(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("deployment failed: %w", err)
 	}
 
-	fmt.Printf("✓ Container deployed successfully\n")
-	fmt.Printf("  ID:    %s\n", container.ID)
-	fmt.Printf("  Name:  %s\n", container.Name)
-	fmt.Printf("  Image: %s\n", container.Image)
-	fmt.Printf("  State: %s\n", container.State)
+	name := container.Name
+	if name == "" {
+		name = container.ID
+	}
+
+	if waitReady {
+		waitCtx, cancelWait := waitContext()
+		defer cancelWait()
+
+		status, err := waitForContainer(waitCtx, client, container.ID)
+		if err != nil {
+			return err
+		}
+		container.Status = status
+	}
+
+	return printResult(container, name, func() {
+		fmt.Printf("✓ Container deployed successfully\n")
+		fmt.Printf("  ID:    %s\n", container.ID)
+		fmt.Printf("  Name:  %s\n", container.Name)
+		fmt.Printf("  Image: %s\n", container.Image)
+		fmt.Printf("  State: %s\n", container.State)
+		if waitReady {
+			fmt.Printf("  Ready: %s\n", container.Status)
+		}
+	})
+}
+
+// parseHealthcheck builds a healthcheck from the --health-* flags, returning
+// nil when none are set so the image's HEALTHCHECK applies unchanged
+func parseHealthcheck(cmd *cobra.Command) (*backend.Healthcheck, error) {
+	flags := []string{"health-cmd", "health-interval", "health-timeout", "health-retries", "health-start-period"}
+
+	changed := false
+	for _, flag := range flags {
+		if cmd.Flags().Changed(flag) {
+			changed = true
+			break
+		}
+	}
+
+	if deployNoHealthcheck {
+		if changed {
+			return nil, fmt.Errorf("--no-healthcheck conflicts with --health-* options")
+		}
+		return &backend.Healthcheck{Test: []string{"NONE"}}, nil
+	}
+	if !changed {
+		return nil, nil
+	}
+
+	durations := []struct {
+		flag  string
+		value time.Duration
+	}{
+		{"health-interval", deployHealthInterval},
+		{"health-timeout", deployHealthTimeout},
+		{"health-start-period", deployHealthStartPeriod},
+	}
+	for _, d := range durations {
+		// Docker rejects non-zero durations below a millisecond
+		if d.value < 0 || (d.value > 0 && d.value < time.Millisecond) {
+			return nil, fmt.Errorf("--%s must be at least 1ms (got %s)", d.flag, d.value)
+		}
+	}
+	if deployHealthRetries < 0 {
+		return nil, fmt.Errorf("--health-retries cannot be negative")
+	}
 
-	return nil
+	healthcheck := &backend.Healthcheck{
+		Interval:    deployHealthInterval,
+		Timeout:     deployHealthTimeout,
+		StartPeriod: deployHealthStartPeriod,
+		Retries:     deployHealthRetries,
+	}
+	if cmd.Flags().Changed("health-cmd") {
+		if strings.TrimSpace(deployHealthCmd) == "" {
+			return nil, fmt.Errorf("--health-cmd cannot be empty (use --no-healthcheck to disable the image's healthcheck)")
+		}
+		healthcheck.Test = []string{"CMD-SHELL", deployHealthCmd}
+	}
+
+	return healthcheck, nil
 }
 
+// validateNetworkFlags checks --network-alias and --ip, which only apply to
+// user-defined networks
+func validateNetworkFlags(network string, aliases []string, ip string) error {
+	if network == "" {
+		if len(aliases) > 0 || ip != "" {
+			return fmt.Errorf("--network-alias and --ip require --network")
+		}
+		return nil
+	}
+
+	builtin := network == "host" || network == "none" || network == "bridge" || network == "default" ||
+		strings.HasPrefix(network, "container:")
+	if builtin && (len(aliases) > 0 || ip != "") {
+		return fmt.Errorf("--network-alias and --ip are only supported on user-defined networks (got %q)", network)
+	}
+
+	return validateIPv4(ip)
+}
+
+// parsePorts parses port mappings using docker's -p grammar:
+// [[HOST_IP:][HOST_PORT[-END]]:]CONTAINER_PORT[-END][/PROTOCOL]
 func parsePorts(ports []string) ([]backend.PortMapping, error) {
 	var mappings []backend.PortMapping
 
 	for _, portStr := range ports {
-		parts := strings.Split(portStr, ":")
-		if len(parts) != 2 {
-			return nil, fmt.Errorf("port must be in format HOST:CONTAINER (got: %s)", portStr)
+		parsed, err := parsePort(portStr)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", portStr, err)
+		}
+		mappings = append(mappings, parsed...)
+	}
+
+	return mappings, nil
+}
+
+// parsePort parses a single -p entry, expanding ranges into one mapping per port
+func parsePort(spec string) ([]backend.PortMapping, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, fmt.Errorf("port mapping cannot be empty")
+	}
+
+	protocol := "tcp"
+	if rest, proto, ok := strings.Cut(spec, "/"); ok {
+		protocol = strings.ToLower(proto)
+		if protocol != "tcp" && protocol != "udp" && protocol != "sctp" {
+			return nil, fmt.Errorf("unsupported protocol %q (use tcp, udp or sctp)", proto)
 		}
+		spec = rest
+	}
 
+	// A bracketed IPv6 host address may itself contain colons
+	hostIP := ""
+	if strings.HasPrefix(spec, "[") {
+		end := strings.Index(spec, "]:")
+		if end < 0 {
+			return nil, fmt.Errorf("IPv6 host address must be written as [ADDRESS]:HOST_PORT:CONTAINER_PORT")
+		}
+		hostIP = spec[1:end]
+		spec = spec[end+2:]
+		if !strings.Contains(spec, ":") {
+			return nil, fmt.Errorf("missing container port after host address")
+		}
+	}
+
+	var hostPart, containerPart string
+	parts := strings.Split(spec, ":")
+	switch len(parts) {
+	case 1:
+		containerPart = parts[0]
+	case 2:
+		hostPart, containerPart = parts[0], parts[1]
+	case 3:
+		if hostIP != "" {
+			return nil, fmt.Errorf("too many colons")
+		}
+		hostIP, hostPart, containerPart = parts[0], parts[1], parts[2]
+		if hostIP == "" {
+			return nil, fmt.Errorf("host IP cannot be empty")
+		}
+	default:
+		return nil, fmt.Errorf("too many colons (use [HOST_IP:][HOST_PORT:]CONTAINER_PORT[/PROTOCOL]; wrap IPv6 addresses in brackets)")
+	}
+
+	if hostIP != "" && net.ParseIP(hostIP) == nil {
+		return nil, fmt.Errorf("invalid host IP %q", hostIP)
+	}
+
+	containerStart, containerEnd, err := parsePortRange(containerPart)
+	if err != nil {
+		return nil, fmt.Errorf("invalid container port: %w", err)
+	}
+
+	// An empty host port lets Docker pick a random one
+	if hostPart == "" {
+		var mappings []backend.PortMapping
+		for port := containerStart; port <= containerEnd; port++ {
+			mappings = append(mappings, backend.PortMapping{
+				HostIP:        hostIP,
+				ContainerPort: strconv.Itoa(port),
+				Protocol:      protocol,
+			})
+		}
+		return mappings, nil
+	}
+
+	hostStart, hostEnd, err := parsePortRange(hostPart)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host port: %w", err)
+	}
+
+	// A host range with a single container port lets Docker pick from the range
+	if containerStart == containerEnd && hostStart != hostEnd {
+		return []backend.PortMapping{{
+			HostIP:        hostIP,
+			HostPort:      hostPart,
+			ContainerPort: strconv.Itoa(containerStart),
+			Protocol:      protocol,
+		}}, nil
+	}
+
+	if hostEnd-hostStart != containerEnd-containerStart {
+		return nil, fmt.Errorf("host range %s and container range %s have different sizes", hostPart, containerPart)
+	}
+
+	var mappings []backend.PortMapping
+	for offset := 0; offset <= containerEnd-containerStart; offset++ {
 		mappings = append(mappings, backend.PortMapping{
-			HostPort:      parts[0],
-			ContainerPort: parts[1],
-			Protocol:      "tcp",
+			HostIP:        hostIP,
+			HostPort:      strconv.Itoa(hostStart + offset),
+			ContainerPort: strconv.Itoa(containerStart + offset),
+			Protocol:      protocol,
 		})
 	}
 
 	return mappings, nil
 }
 
+// parsePortRange parses "PORT" or "START-END" into an inclusive range
+func parsePortRange(value string) (int, int, error) {
+	if value == "" {
+		return 0, 0, fmt.Errorf("port cannot be empty")
+	}
+
+	startStr, endStr, isRange := strings.Cut(value, "-")
+
+	start, err := parsePortNumber(startStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !isRange {
+		return start, start, nil
+	}
+
+	end, err := parsePortNumber(endStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	if end < start {
+		return 0, 0, fmt.Errorf("range %s ends before it starts", value)
+	}
+
+	return start, end, nil
+}
+
+func parsePortNumber(value string) (int, error) {
+	port, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", value)
+	}
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("%d is out of range (1-65535)", port)
+	}
+	return port, nil
+}
+
 func parseEnv(envVars []string) (map[string]string, error) {
 	envMap := make(map[string]string)
 
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+)
+
+func TestParsePort(t *testing.T) {
+	tcp := func(hostIP, host, container string) backend.PortMapping {
+		return backend.PortMapping{HostIP: hostIP, HostPort: host, ContainerPort: container, Protocol: "tcp"}
+	}
+
+	tests := []struct {
+		spec    string
+		want    []backend.PortMapping
+		wantErr string
+	}{
+		{spec: "80", want: []backend.PortMapping{tcp("", "", "80")}},
+		{spec: "8080:80", want: []backend.PortMapping{tcp("", "8080", "80")}},
+		{spec: " 8080:80 ", want: []backend.PortMapping{tcp("", "8080", "80")}},
+		{spec: ":80", want: []backend.PortMapping{tcp("", "", "80")}},
+		{spec: "127.0.0.1:8080:80", want: []backend.PortMapping{tcp("127.0.0.1", "8080", "80")}},
+		{spec: "127.0.0.1::80", want: []backend.PortMapping{tcp("127.0.0.1", "", "80")}},
+		{spec: "[::1]:8080:80", want: []backend.PortMapping{tcp("::1", "8080", "80")}},
+		{spec: "[::1]::80", want: []backend.PortMapping{tcp("::1", "", "80")}},
+		{spec: "53:53/udp", want: []backend.PortMapping{{HostPort: "53", ContainerPort: "53", Protocol: "udp"}}},
+		{spec: "53:53/UDP", want: []backend.PortMapping{{HostPort: "53", ContainerPort: "53", Protocol: "udp"}}},
+		{spec: "8000-8001:9000-9001", want: []backend.PortMapping{tcp("", "8000", "9000"), tcp("", "8001", "9001")}},
+		{spec: "9000-9001", want: []backend.PortMapping{tcp("", "", "9000"), tcp("", "", "9001")}},
+		{spec: "8000-8010:80", want: []backend.PortMapping{tcp("", "8000-8010", "80")}},
+
+		{spec: "", wantErr: "cannot be empty"},
+		{spec: "80/icmp", wantErr: "unsupported protocol"},
+		{spec: "http", wantErr: "invalid container port"},
+		{spec: "8080:", wantErr: "invalid container port"},
+		{spec: "70000", wantErr: "invalid container port"},
+		{spec: "x:80", wantErr: "invalid host port"},
+		{spec: ":8080:80", wantErr: "host IP cannot be empty"},
+		{spec: "localhost:8080:80", wantErr: "invalid host IP"},
+		{spec: "::1:8080:80", wantErr: "too many colons"},
+		{spec: "[::1]:80", wantErr: "missing container port"},
+		{spec: "[::1:8080:80", wantErr: "IPv6 host address"},
+		{spec: "8000-8002:9000-9001", wantErr: "different sizes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := parsePort(tt.spec)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parsePort(%q) error = %v, want %q", tt.spec, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsePort(%q) unexpected error: %v", tt.spec, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePort(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+)
+
+// dockerHubServer is the key Docker uses for Docker Hub credentials
+const dockerHubServer = "https://index.docker.io/v1/"
+
+// dockerConfig is the subset of ~/.docker/config.json used for credentials
+type dockerConfig struct {
+	Auths map[string]struct {
+		Auth          string `json:"auth"`
+		IdentityToken string `json:"identitytoken"`
+	} `json:"auths"`
+	CredsStore  string            `json:"credsStore"`
+	CredHelpers map[string]string `json:"credHelpers"`
+}
+
+// registryHost returns the registry an image reference points at, using
+// docker's rule that the first path component is a host only if it looks
+// like one
+func registryHost(image string) string {
+	first, _, ok := strings.Cut(image, "/")
+	if !ok || (!strings.ContainsAny(first, ".:") && first != "localhost") {
+		return "docker.io"
+	}
+	return authsKeyHost(first)
+}
+
+// registryServer returns the server address docker uses for a registry host
+func registryServer(host string) string {
+	if host == "docker.io" {
+		return dockerHubServer
+	}
+	return host
+}
+
+// dockerCredentials looks up credentials for a registry host the way the
+// docker CLI does: a per-registry credential helper, then the default
+// credential store, then the auths section. It returns nil if none are found.
+func dockerCredentials(host string) (*backend.RegistryAuth, error) {
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, nil
+		}
+		dir = filepath.Join(home, ".docker")
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read docker config: %w", err)
+	}
+
+	var cfg dockerConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse docker config: %w", err)
+	}
+
+	server := registryServer(host)
+
+	if helper := cfg.CredHelpers[host]; helper != "" {
+		return credentialHelper(helper, server)
+	}
+	if cfg.CredsStore != "" {
+		return credentialHelper(cfg.CredsStore, server)
+	}
+
+	for key, entry := range cfg.Auths {
+		if authsKeyHost(key) != host {
+			continue
+		}
+
+		auth := &backend.RegistryAuth{ServerAddress: server, IdentityToken: entry.IdentityToken}
+		if entry.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("invalid auth for %s in docker config: %w", key, err)
+			}
+			username, password, ok := strings.Cut(string(decoded), ":")
+			if !ok {
+				return nil, fmt.Errorf("invalid auth for %s in docker config: expected username:password", key)
+			}
+			auth.Username, auth.Password = username, password
+		}
+		return auth, nil
+	}
+
+	return nil, nil
+}
+
+// authsKeyHost normalizes an auths key such as "https://index.docker.io/v1/"
+// to the registry host it is for
+func authsKeyHost(key string) string {
+	key = strings.TrimPrefix(key, "https://")
+	key = strings.TrimPrefix(key, "http://")
+	host, _, _ := strings.Cut(key, "/")
+
+	if host == "index.docker.io" || host == "registry-1.docker.io" {
+		return "docker.io"
+	}
+	return host
+}
+
+// credentialHelper runs docker-credential-<helper> get for a server
+func credentialHelper(helper, server string) (*backend.RegistryAuth, error) {
+	cmd := exec.Command("docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(server)
+
+	out, err := cmd.Output()
+	if err != nil {
+		// Helpers report a missing entry on stdout with a non-zero exit
+		if strings.Contains(string(out), "credentials not found") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("credential helper docker-credential-%s failed: %w", helper, err)
+	}
+
+	var creds struct {
+		Username string `json:"Username"`
+		Secret   string `json:"Secret"`
+	}
+	if err := json.Unmarshal(out, &creds); err != nil {
+		return nil, fmt.Errorf("failed to parse output of docker-credential-%s: %w", helper, err)
+	}
+
+	auth := &backend.RegistryAuth{ServerAddress: server}
+	if creds.Username == "<token>" {
+		auth.IdentityToken = creds.Secret
+	} else {
+		auth.Username, auth.Password = creds.Username, creds.Secret
+	}
+
+	return auth, nil
+}
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend/portainer"
+	"github.com/Elias-Larsson/remdoc/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var endpointsCmd = &cobra.Command{
+	Use:   "endpoints",
+	Short: "List Portainer endpoints (environments)",
+	Long: `List the Docker environments managed by the Portainer instance.
+
+Use the ID or name with the global --endpoint flag to target a specific
+environment, or save it as the default with 'remdoc endpoints use'.
+
+Examples:
+  remdoc endpoints
+  remdoc endpoints use production
+  remdoc status --endpoint 3`,
+	Args: cobra.NoArgs,
+	RunE: runEndpoints,
+}
+
+var endpointsUseCmd = &cobra.Command{
+	Use:   "use <endpoint>",
+	Short: "Set the default endpoint",
+	Long: `Save an endpoint (by ID or name) as the default for all commands.
+
+Examples:
+  remdoc endpoints use 2
+  remdoc endpoints use production`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEndpointsUse,
+}
+
+func init() {
+	endpointsCmd.AddCommand(endpointsUseCmd)
+	rootCmd.AddCommand(endpointsCmd)
+}
+
+func runEndpoints(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	endpoints, err := client.ListEndpoints(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch endpoints: %w", err)
+	}
+
+	// Mark the endpoint commands would currently target
+	selected := 0
+	if current, err := portainer.SelectEndpoint(endpoints, client.Endpoint); err == nil {
+		selected = current.ID
+	}
+
+	rows := make([]endpointRow, len(endpoints))
+	for i, e := range endpoints {
+		rows[i] = endpointRow{
+			ID:      e.ID,
+			Name:    e.Name,
+			Type:    e.TypeName(),
+			URL:     e.URL,
+			Status:  e.StatusName(),
+			Default: e.ID == selected,
+		}
+	}
+
+	return printList(rows, func(r endpointRow) string { return r.Name }, func() {
+		if len(rows) == 0 {
+			fmt.Println("No endpoints found.")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tTYPE\tURL\tSTATUS\tDEFAULT")
+
+		for _, r := range rows {
+			marker := ""
+			if r.Default {
+				marker = "*"
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", r.ID, r.Name, r.Type, r.URL, r.Status, marker)
+		}
+
+		w.Flush()
+	})
+}
+
+// endpointRow is the printable form of a Portainer endpoint
+type endpointRow struct {
+	ID      int    `json:"id" yaml:"id"`
+	Name    string `json:"name" yaml:"name"`
+	Type    string `json:"type" yaml:"type"`
+	URL     string `json:"url" yaml:"url"`
+	Status  string `json:"status" yaml:"status"`
+	Default bool   `json:"default" yaml:"default"`
+}
+
+func runEndpointsUse(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	endpoint, err := client.FindEndpoint(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	cfg, name, current, err := loadContext()
+	if err != nil {
+		return err
+	}
+
+	current.Endpoint = strconv.Itoa(endpoint.ID)
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Printf("✓ Default endpoint for context %s set to %s (ID: %d)\n", name, endpoint.Name, endpoint.ID)
+	return nil
+}
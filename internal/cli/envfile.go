@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+)
+
+// readEnvFiles reads .env files in order and returns their variables as
+// KEY=VALUE entries, so they can be combined with --env flags
+func readEnvFiles(paths []string) ([]string, error) {
+	var entries []string
+
+	for _, path := range paths {
+		fileEntries, err := readEnvFile(path)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, fileEntries...)
+	}
+
+	return entries, nil
+}
+
+// readEnvFile parses a .env file. Blank lines and # comments are skipped, an
+// "export " prefix is allowed, and values may be wrapped in single or double
+// quotes. A bare KEY takes its value from the local environment, if set.
+func readEnvFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read env file: %w", err)
+	}
+	defer file.Close()
+
+	var entries []string
+	scanner := bufio.NewScanner(file)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, hasValue := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("%s:%d: invalid variable name %q", path, lineNo, key)
+		}
+
+		if !hasValue {
+			if local, ok := os.LookupEnv(key); ok {
+				entries = append(entries, key+"="+local)
+			}
+			continue
+		}
+
+		entries = append(entries, key+"="+unquoteEnvValue(strings.TrimSpace(value)))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read env file %s: %w", path, err)
+	}
+
+	return entries, nil
+}
+
+// unquoteEnvValue strips matching quotes from a .env value, or a trailing
+// " # comment" from an unquoted one
+func unquoteEnvValue(value string) string {
+	if len(value) >= 2 {
+		quote := value[0]
+		if (quote == '"' || quote == '\'') && value[len(value)-1] == quote {
+			value = value[1 : len(value)-1]
+			if quote == '"' {
+				value = strings.NewReplacer(`\n`, "\n", `\"`, `"`, `\\`, `\`).Replace(value)
+			}
+			return value
+		}
+	}
+
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return value
+}
+
+// parseEnvList parses KEY=VALUE entries into an ordered list for stacks.
+// A repeated key keeps its first position and takes the last value.
+func parseEnvList(entries []string) ([]backend.EnvVar, error) {
+	var vars []backend.EnvVar
+	index := make(map[string]int)
+
+	for _, entry := range entries {
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("env var must be in format KEY=value (got: %s)", entry)
+		}
+
+		if i, seen := index[key]; seen {
+			vars[i].Value = value
+			continue
+		}
+		index[key] = len(vars)
+		vars = append(vars, backend.EnvVar{Name: key, Value: value})
+	}
+
+	return vars, nil
+}
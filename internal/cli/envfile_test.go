@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+)
+
+func TestReadEnvFile(t *testing.T) {
+	t.Setenv("REMDOC_TEST_LOCAL", "from-shell")
+
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:    "plain values",
+			content: "A=1\nB=two words\n",
+			want:    []string{"A=1", "B=two words"},
+		},
+		{
+			name:    "comments, blank lines and export",
+			content: "# settings\n\nexport A=1\n  B = 2  \n",
+			want:    []string{"A=1", "B=2"},
+		},
+		{
+			name:    "empty value",
+			content: "A=\n",
+			want:    []string{"A="},
+		},
+		{
+			name:    "value containing equals",
+			content: "URL=postgres://db?sslmode=disable\n",
+			want:    []string{"URL=postgres://db?sslmode=disable"},
+		},
+		{
+			name:    "inline comment on unquoted value",
+			content: "A=1 # the first\nB=a#b\n",
+			want:    []string{"A=1", "B=a#b"},
+		},
+		{
+			name:    "single quotes are literal",
+			content: `A='x # y \n'` + "\n",
+			want:    []string{`A=x # y \n`},
+		},
+		{
+			name:    "double quotes unescape",
+			content: `A="line1\nline2 \"q\" \\"` + "\n",
+			want:    []string{"A=line1\nline2 \"q\" \\"},
+		},
+		{
+			name:    "unmatched quote is kept",
+			content: `A="open` + "\n",
+			want:    []string{`A="open`},
+		},
+		{
+			name:    "bare key takes the local value",
+			content: "REMDOC_TEST_LOCAL\nREMDOC_TEST_UNSET\n",
+			want:    []string{"REMDOC_TEST_LOCAL=from-shell"},
+		},
+		{
+			name:    "missing key",
+			content: "A=1\n=2\n",
+			wantErr: ":2: invalid variable name",
+		},
+		{
+			name:    "key with spaces",
+			content: "MY VAR=1\n",
+			wantErr: ":1: invalid variable name \"MY VAR\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".env")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := readEnvFile(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readEnvFile = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadEnvFileMissing(t *testing.T) {
+	_, err := readEnvFile(filepath.Join(t.TempDir(), "missing.env"))
+	if err == nil || !strings.Contains(err.Error(), "failed to read env file") {
+		t.Fatalf("error = %v, want a read error", err)
+	}
+}
+
+func TestParseEnvList(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []string
+		want    []backend.EnvVar
+		wantErr string
+	}{
+		{
+			name:    "keeps order",
+			entries: []string{"B=2", "A=1"},
+			want:    []backend.EnvVar{{Name: "B", Value: "2"}, {Name: "A", Value: "1"}},
+		},
+		{
+			name:    "later value wins in the first position",
+			entries: []string{"A=file", "B=2", "A=flag"},
+			want:    []backend.EnvVar{{Name: "A", Value: "flag"}, {Name: "B", Value: "2"}},
+		},
+		{
+			name:    "value may contain equals and be empty",
+			entries: []string{"A=x=y", "B="},
+			want:    []backend.EnvVar{{Name: "A", Value: "x=y"}, {Name: "B", Value: ""}},
+		},
+		{name: "no equals", entries: []string{"A"}, wantErr: "KEY=value"},
+		{name: "empty key", entries: []string{"=1"}, wantErr: "KEY=value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEnvList(tt.entries)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseEnvList(%q) error = %v, want %q", tt.entries, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseEnvList(%q) unexpected error: %v", tt.entries, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseEnvList(%q) = %+v, want %+v", tt.entries, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+	"github.com/spf13/cobra"
+)
+
+var (
+	eventsSince   string
+	eventsUntil   string
+	eventsFilters []string
+)
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Stream events from the remote Docker host",
+	Long: `Stream real-time events from the remote Docker host, such as containers
+starting, dying or running out of memory, images being pulled and volumes
+being created.
+
+Events are streamed until interrupted, or until --until is reached. --since
+and --until accept a relative duration (e.g. 10m, 2h), an RFC 3339 timestamp
+or a Unix timestamp. With -o json, each event is written as one JSON object
+per line.
+
+Examples:
+  remdoc events
+  remdoc events --filter type=container,event=die
+  remdoc events --filter container=api --filter event=oom
+  remdoc events --since 1h --until 0s
+  remdoc events -o json | jq -c 'select(.action == "die")'`,
+	Args: cobra.NoArgs,
+	RunE: runEvents,
+}
+
+func init() {
+	eventsCmd.Flags().StringVar(&eventsSince, "since", "", "Show events since a timestamp or relative duration (e.g. 10m)")
+	eventsCmd.Flags().StringVar(&eventsUntil, "until", "", "Stop streaming at a timestamp or relative duration (e.g. 0s for now)")
+	eventsCmd.Flags().StringArrayVar(&eventsFilters, "filter", []string{}, "Filter events (e.g. type=container,event=die,container=api,image=nginx,label=app=web)")
+	rootCmd.AddCommand(eventsCmd)
+}
+
+func runEvents(cmd *cobra.Command, args []string) error {
+	if outputFlag == outputYAML || outputFlag == outputName {
+		return fmt.Errorf("-o %s is not supported for events (use json or --format)", outputFlag)
+	}
+
+	filters, err := parseFilters(eventsFilters, nil)
+	if err != nil {
+		return err
+	}
+
+	since, err := parseTimeArg(eventsSince)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+
+	until, err := parseTimeArg(eventsUntil)
+	if err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	opts := backend.EventsOptions{
+		Since:   since,
+		Until:   until,
+		Filters: filters,
+	}
+
+	// Stop streaming if stdout goes away, e.g. a closed pipe
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var writeErr error
+	err = client.Events(ctx, opts, func(e backend.Event) {
+		if writeErr == nil {
+			if writeErr = writeEvent(e); writeErr != nil {
+				cancel()
+			}
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to stream events: %w", err)
+	}
+
+	return writeErr
+}
+
+// writeEvent prints an event as a JSON line, with --format, or in docker
+// events' human-readable form
+func writeEvent(e backend.Event) error {
+	switch {
+	case formatFlag != "":
+		return writeTemplate(os.Stdout, formatFlag, e)
+	case outputFlag == outputJSON:
+		return writeJSONLine(os.Stdout, e)
+	}
+
+	keys := make([]string, 0, len(e.Attributes))
+	for key := range e.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]string, len(keys))
+	for i, key := range keys {
+		attrs[i] = key + "=" + e.Attributes[key]
+	}
+
+	line := fmt.Sprintf("%s %s %s %s", e.Time.Local().Format("2006-01-02T15:04:05.000000000Z07:00"), e.Type, e.Action, e.ActorID)
+	if len(attrs) > 0 {
+		line += " (" + strings.Join(attrs, ", ") + ")"
+	}
+
+	_, err := fmt.Println(line)
+	return err
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	execInteractive bool
+	execTTY         bool
+)
+
+var execCmd = &cobra.Command{
+	Use:   "exec <container> -- <command> [args...]",
+	Short: "Run a command in a running container",
+	Long: `Run a command inside a running container on the remote server.
+
+Use -i to attach your input and -t to allocate a pseudo-TTY; together they
+give an interactive shell. The command's exit code is passed through, so exec
+can be used in scripts.
+
+Examples:
+  remdoc exec -it my-nginx -- /bin/sh
+  remdoc exec my-db -- pg_isready -U postgres
+  remdoc exec my-app -- ls -la /app`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runExec,
+}
+
+func init() {
+	execCmd.Flags().BoolVarP(&execInteractive, "interactive", "i", false, "Keep stdin attached to the command")
+	execCmd.Flags().BoolVarP(&execTTY, "tty", "t", false, "Allocate a pseudo-TTY")
+	// Flags after the container name belong to the remote command
+	execCmd.Flags().SetInterspersed(false)
+	rootCmd.AddCommand(execCmd)
+}
+
+func runExec(cmd *cobra.Command, args []string) error {
+	containerID := args[0]
+	command := args[1:]
+	if dash := cmd.ArgsLenAtDash(); dash == 1 {
+		command = args[dash:]
+	} else if len(command) > 0 && command[0] == "--" {
+		command = command[1:]
+	}
+
+	if len(command) == 0 {
+		return fmt.Errorf("no command given (usage: remdoc exec <container> -- <command> [args...])")
+	}
+
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	opts := backend.ExecOptions{
+		Cmd:         command,
+		TTY:         execTTY,
+		Interactive: execInteractive,
+		Stdin:       os.Stdin,
+		Stdout:      os.Stdout,
+		Stderr:      os.Stderr,
+	}
+
+	stdinFd := int(os.Stdin.Fd())
+	if execTTY && execInteractive && term.IsTerminal(stdinFd) {
+		state, err := term.MakeRaw(stdinFd)
+		if err != nil {
+			return fmt.Errorf("failed to set terminal to raw mode: %w", err)
+		}
+		defer term.Restore(stdinFd, state)
+
+		// Ctrl+C now reaches the remote process instead of cancelling us
+		stop()
+		ctx = context.Background()
+	}
+
+	if execTTY {
+		resizeCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		opts.Resize = watchTerminalSize(resizeCtx, int(os.Stdout.Fd()))
+	}
+
+	exitCode, err := client.Exec(ctx, containerID, opts)
+	if err != nil {
+		return fmt.Errorf("exec failed: %w", err)
+	}
+
+	if exitCode != 0 {
+		// The remote command already reported its failure; only pass the code on
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		return &exitError{code: exitCode}
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"crypto/rand"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Elias-Larsson/remdoc/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// Git credential flags, shared by commands that deploy from a repository
+var (
+	gitUsername string
+	gitPassword string
+)
+
+// addGitAuthFlags registers the Git credential flags on a command
+func addGitAuthFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&gitUsername, "git-username", "", "Username for a private Git repository")
+	cmd.Flags().StringVar(&gitPassword, "git-password", "", "Password or access token for the Git repository (or set "+config.GitPasswordEnv+")")
+}
+
+// gitCredentials returns the Git credentials from the flags. Both are empty
+// for public repositories, or to keep the credentials stored in Portainer.
+func gitCredentials() (string, string, error) {
+	password := gitPassword
+	if password == "" {
+		password = os.Getenv(config.GitPasswordEnv)
+	}
+
+	if gitUsername == "" {
+		if gitPassword != "" {
+			return "", "", fmt.Errorf("--git-password requires --git-username")
+		}
+		return "", "", nil
+	}
+	if password == "" {
+		return "", "", fmt.Errorf("--git-username requires --git-password or %s", config.GitPasswordEnv)
+	}
+
+	return gitUsername, password, nil
+}
+
+// repositoryName derives a stack name from a repository URL, e.g.
+// https://github.com/acme/shop.git becomes shop
+func repositoryName(url string) string {
+	url = strings.TrimSuffix(strings.TrimRight(url, "/"), ".git")
+	if i := strings.LastIndexAny(url, "/:"); i >= 0 {
+		url = url[i+1:]
+	}
+	return url
+}
+
+// newWebhookID returns a random UUID for a stack's redeploy webhook
+func newWebhookID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook ID: %w", err)
+	}
+
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
@@ -0,0 +1,316 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+	"github.com/spf13/cobra"
+)
+
+var (
+	imageAll          bool
+	imageFilters      []string
+	imageRmForce      bool
+	imagePruneForce   bool
+	imagePruneAll     bool
+	imagePruneFilters []string
+)
+
+var imageCmd = &cobra.Command{
+	Use:   "image",
+	Short: "Manage images on the remote server",
+	Long: `List, pull, inspect and remove Docker images on the remote server.
+
+Examples:
+  remdoc image ls
+  remdoc image pull nginx:1.27
+  remdoc image inspect nginx:1.27 --format '{{.Architecture}}'
+  remdoc image rm nginx:1.25
+  remdoc image prune --all`,
+}
+
+var imageLsCmd = &cobra.Command{
+	Use:     "ls",
+	Aliases: []string{"list"},
+	Short:   "List images",
+	Args:    cobra.NoArgs,
+	RunE:    runImageLs,
+}
+
+var imagePullCmd = &cobra.Command{
+	Use:   "pull <image>...",
+	Short: "Pull images onto the remote server",
+	Long: `Pull images onto the remote server, showing per-layer progress.
+
+If no tag is given, "latest" is pulled. Private registries use credentials
+from --registry, --registry-username/--registry-password or ~/.docker/config.json
+(see 'remdoc registry --help').`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runImagePull,
+}
+
+var imageInspectCmd = &cobra.Command{
+	Use:   "inspect <image>...",
+	Short: "Show detailed information about images",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runImageInspect,
+}
+
+var imageRmCmd = &cobra.Command{
+	Use:     "rm <image>...",
+	Aliases: []string{"remove", "rmi"},
+	Short:   "Remove images",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    runImageRm,
+}
+
+var imagePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove unused images",
+	Long: `Remove images not used by any container.
+
+By default only dangling (untagged) images are removed; use --all to remove
+every image without a container.`,
+	Args: cobra.NoArgs,
+	RunE: runImagePrune,
+}
+
+func init() {
+	imageLsCmd.Flags().BoolVarP(&imageAll, "all", "a", false, "Show all images, including intermediate layers")
+	imageLsCmd.Flags().StringArrayVar(&imageFilters, "filter", []string{}, "Filter images (e.g. reference=nginx,dangling=true,label=app=web,before=redis:7)")
+
+	addRegistryFlags(imagePullCmd)
+
+	imageRmCmd.Flags().BoolVarP(&imageRmForce, "force", "f", false, "Force removal of images used by stopped containers or with several tags")
+
+	imagePruneCmd.Flags().BoolVarP(&imagePruneForce, "force", "f", false, "Do not prompt for confirmation")
+	imagePruneCmd.Flags().BoolVarP(&imagePruneAll, "all", "a", false, "Remove all unused images, not just dangling ones")
+	imagePruneCmd.Flags().StringArrayVar(&imagePruneFilters, "filter", []string{}, "Only prune images matching a filter (e.g. until=24h,label=tmp)")
+
+	imageCmd.AddCommand(imageLsCmd, imagePullCmd, imageInspectCmd, imageRmCmd, imagePruneCmd)
+	rootCmd.AddCommand(imageCmd)
+}
+
+// imageRow is a single REPOSITORY:TAG line of image ls
+type imageRow struct {
+	repository string
+	tag        string
+	image      backend.Image
+}
+
+func runImageLs(cmd *cobra.Command, args []string) error {
+	filters, err := parseFilters(imageFilters, nil)
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	images, err := client.ListImages(ctx, imageAll, filters)
+	if err != nil {
+		return fmt.Errorf("failed to fetch images: %w", err)
+	}
+
+	return printList(images, func(img backend.Image) string { return imageName(img) }, func() {
+		if len(images) == 0 {
+			fmt.Println("No images found.")
+			return
+		}
+
+		// Like docker images, an image with several tags gets a row per tag
+		var rows []imageRow
+		for _, img := range images {
+			if len(img.RepoTags) == 0 {
+				rows = append(rows, imageRow{repository: "<none>", tag: "<none>", image: img})
+				continue
+			}
+			for _, ref := range img.RepoTags {
+				i := strings.LastIndex(ref, ":")
+				rows = append(rows, imageRow{repository: ref[:i], tag: ref[i+1:], image: img})
+			}
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		if outputFlag == outputWide {
+			fmt.Fprintln(w, "REPOSITORY\tTAG\tIMAGE ID\tCREATED\tSIZE\tDIGEST")
+		} else {
+			fmt.Fprintln(w, "REPOSITORY\tTAG\tIMAGE ID\tCREATED\tSIZE")
+		}
+
+		for _, row := range rows {
+			created := row.image.Created.Local().Format("2006-01-02 15:04:05")
+
+			if outputFlag == outputWide {
+				digest := "<none>"
+				if len(row.image.RepoDigests) > 0 {
+					_, digest, _ = strings.Cut(row.image.RepoDigests[0], "@")
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", row.repository, row.tag, shortImageID(row.image.ID),
+					created, formatBytes(row.image.Size), digest)
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", row.repository, row.tag, shortImageID(row.image.ID),
+				created, formatBytes(row.image.Size))
+		}
+
+		w.Flush()
+	})
+}
+
+// imageName returns an image's first tag, or its ID if it is untagged
+func imageName(img backend.Image) string {
+	if len(img.RepoTags) > 0 {
+		return img.RepoTags[0]
+	}
+	return img.ID
+}
+
+// shortImageID strips the digest algorithm and truncates like docker images
+func shortImageID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		id = id[:12]
+	}
+	return id
+}
+
+func runImagePull(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var results []resourceResult
+	for _, image := range args {
+		if err := pullImage(ctx, client, image); err != nil {
+			return err
+		}
+		results = append(results, resourceResult{Action: "pull", Kind: "image", Name: image, Status: "pulled"})
+	}
+
+	return printList(results, func(r resourceResult) string { return r.Name }, func() {
+		fmt.Println("✓ Image(s) pulled successfully")
+	})
+}
+
+func runImageInspect(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	images := make([]backend.ImageDetails, 0, len(args))
+	for _, name := range args {
+		image, err := client.InspectImage(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to inspect image: %w", err)
+		}
+		images = append(images, *image)
+	}
+
+	// Like docker image inspect, the human-readable default is JSON
+	if !machineOutput() {
+		return writeJSON(os.Stdout, images)
+	}
+
+	return printList(images, func(img backend.ImageDetails) string { return imageName(img.Image) }, nil)
+}
+
+func runImageRm(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	removal := backend.ImageRemoval{Untagged: []string{}, Deleted: []string{}}
+	for _, image := range args {
+		progressf("Removing image %s...\n", image)
+
+		result, err := client.RemoveImage(ctx, image, imageRmForce)
+		if err != nil {
+			return fmt.Errorf("failed to remove image: %w", err)
+		}
+		removal.Untagged = append(removal.Untagged, result.Untagged...)
+		removal.Deleted = append(removal.Deleted, result.Deleted...)
+	}
+
+	return printResult(removal, strings.Join(append(removal.Untagged, removal.Deleted...), "\n"), func() {
+		for _, ref := range removal.Untagged {
+			fmt.Printf("  Untagged: %s\n", ref)
+		}
+		for _, id := range removal.Deleted {
+			fmt.Printf("  Deleted: %s\n", id)
+		}
+		fmt.Println("✓ Image(s) removed successfully")
+	})
+}
+
+func runImagePrune(cmd *cobra.Command, args []string) error {
+	filters, err := parseFilters(imagePruneFilters, nil)
+	if err != nil {
+		return err
+	}
+
+	// Docker only prunes untagged images unless dangling=false is set
+	if imagePruneAll {
+		filters["dangling"] = []string{"false"}
+	}
+
+	if !imagePruneForce {
+		question := "This will remove all dangling images. Continue?"
+		if imagePruneAll {
+			question = "This will remove all images without at least one container associated to them. Continue?"
+		}
+		confirmed, err := confirm(question)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return fmt.Errorf("prune cancelled")
+		}
+	}
+
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	report, err := client.PruneImages(ctx, filters)
+	if err != nil {
+		return fmt.Errorf("failed to prune images: %w", err)
+	}
+
+	return printResult(report, strings.Join(report.Deleted, "\n"), func() {
+		for _, ref := range report.Untagged {
+			fmt.Printf("  Untagged: %s\n", ref)
+		}
+		for _, id := range report.Deleted {
+			fmt.Printf("  Deleted: %s\n", id)
+		}
+		fmt.Printf("✓ Pruned %d image layer(s), reclaimed %s\n", len(report.Deleted), formatBytes(report.SpaceReclaimed))
+	})
+}
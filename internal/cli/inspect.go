@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var inspectCmd = &cobra.Command{
+	Use:   "inspect <container>",
+	Short: "Show detailed information about a container",
+	Long: `Show the state, configuration, mounts, networks and health of a container.
+
+The container can be specified by ID or name. --format takes a Go template
+evaluated against the container details, like 'docker inspect --format'.
+The default output is JSON; use --output yaml for YAML.
+
+Examples:
+  remdoc inspect my-nginx
+  remdoc inspect my-nginx --output yaml
+  remdoc inspect my-nginx --format '{{.State.Status}}'
+  remdoc inspect my-db --format '{{range .Mounts}}{{.Source}} -> {{.Destination}}{{"\n"}}{{end}}'
+  remdoc inspect my-app --format '{{json .Labels}}'`,
+	Args: cobra.ExactArgs(1),
+	RunE: runInspect,
+}
+
+func init() {
+	rootCmd.AddCommand(inspectCmd)
+}
+
+func runInspect(cmd *cobra.Command, args []string) error {
+	containerID := args[0]
+
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	details, err := client.InspectContainer(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("failed to inspect container: %w", err)
+	}
+
+	// Like docker inspect, the human-readable default is JSON
+	if !machineOutput() {
+		return writeJSON(os.Stdout, details)
+	}
+
+	return printResult(details, details.Name, nil)
+}
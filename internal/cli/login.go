@@ -19,69 +19,126 @@ import (
 )
 
 var (
-	username string
-	password string
+	username    string
+	password    string
+	loginURL    string
+	loginAPIKey bool
 )
 
 var loginCmd = &cobra.Command{
 	Use:   "login",
 	Short: "Authenticate with a Portainer instance",
-	Long: `Authenticate with Portainer using your username and password.
+	Long: `Authenticate with Portainer using your username and password, or
+with a Portainer access token.
 
 Your credentials will be used to obtain a JWT token, which will be
-saved in ~/.remdoc/config.json (permissions: 0600).
+saved in ~/.remdoc/config.json (permissions: 0600). JWTs expire; for CI
+and other unattended use, create an access token in Portainer (My account
+> Access tokens) and log in with --api-key instead. The token is read from
+REMDOC_API_KEY if set, otherwise prompted for securely.
+
+The token is stored in the current context, or in the context named by
+--context / REMDOC_CONTEXT (created if it does not exist yet).
 
 Usage:
   remdoc login --username admin
-  remdoc login -u admin -p yourpassword`,
+  remdoc login -u admin -p yourpassword
+  remdoc login -u admin --context staging
+  remdoc login --api-key --url https://portainer.example.com`,
 	RunE: runLogin,
 }
 
 func init() {
-	loginCmd.Flags().StringVarP(&username, "username", "u", "", "Portainer username (required)")
+	loginCmd.Flags().StringVarP(&username, "username", "u", "", "Portainer username (required unless --api-key is used)")
 	loginCmd.Flags().StringVarP(&password, "password", "p", "", "Portainer password (will prompt securely if not provided)")
-	loginCmd.MarkFlagRequired("username")
+	loginCmd.Flags().StringVar(&loginURL, "url", "", "Portainer URL (will prompt if not provided)")
+	loginCmd.Flags().BoolVar(&loginAPIKey, "api-key", false, "Authenticate with a Portainer access token instead of a password (read from "+config.APIKeyEnv+" or prompted)")
+	loginCmd.MarkFlagsMutuallyExclusive("api-key", "username")
+	loginCmd.MarkFlagsMutuallyExclusive("api-key", "password")
 	rootCmd.AddCommand(loginCmd)
 }
 
 func runLogin(cmd *cobra.Command, args []string) error {
-	reader := bufio.NewReader(os.Stdin)
-
-	fmt.Print("Portainer URL (e.g., https://portainer.example.com): ")
-	url, err := reader.ReadString('\n')
+	cfg, err := config.LoadOrNew()
 	if err != nil {
-		return fmt.Errorf("failed to read URL: %w", err)
+		return err
 	}
-	url = strings.TrimSpace(url)
 
-	if url == "" {
-		return fmt.Errorf("URL cannot be empty")
+	name := loginContextName(cfg)
+
+	// Contexts created with 'remdoc context add' already know their URL
+	knownURL := ""
+	if existing, ok := cfg.Contexts[name]; ok {
+		knownURL = existing.PortainerURL
 	}
 
-	if password == "" {
-		fmt.Print("Password: ")
-		passwordBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
-		fmt.Println() 
+	url := strings.TrimSpace(loginURL)
+	if url == "" {
+		reader := bufio.NewReader(os.Stdin)
+
+		if knownURL != "" {
+			fmt.Printf("Portainer URL [%s]: ", knownURL)
+		} else {
+			fmt.Print("Portainer URL (e.g., https://portainer.example.com): ")
+		}
+		input, err := reader.ReadString('\n')
 		if err != nil {
-			return fmt.Errorf("failed to read password: %w", err)
+			return fmt.Errorf("failed to read URL: %w", err)
+		}
+		url = strings.TrimSpace(input)
+		if url == "" {
+			url = knownURL
 		}
-		password = string(passwordBytes)
 	}
 
-	if password == "" {
-		return fmt.Errorf("password cannot be empty")
+	if url == "" {
+		return fmt.Errorf("URL cannot be empty")
 	}
 
-	fmt.Print("Authenticating... ")
-	jwt, err := getJWTFromPortainer(url, username, password)
-	if err != nil {
-		fmt.Println("✗")
-		return fmt.Errorf("authentication failed: %w", err)
-	}
-	fmt.Println("✓")
+	loginCtx := &config.Context{PortainerURL: url}
+	var client *portainer.Client
+
+	if loginAPIKey {
+		apiKey, err := readAPIKey()
+		if err != nil {
+			return err
+		}
+
+		loginCtx.APIKey = apiKey
+		client = portainer.NewAPIKeyClient(url, apiKey)
+		fmt.Print("Validating access token... ")
+	} else {
+		if username == "" {
+			return fmt.Errorf("--username is required (or use --api-key for access token authentication)")
+		}
+
+		if password == "" {
+			fmt.Print("Password: ")
+			passwordBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
+			fmt.Println()
+			if err != nil {
+				return fmt.Errorf("failed to read password: %w", err)
+			}
+			password = string(passwordBytes)
+		}
 
-	fmt.Print("Validating credentials... ")
-	client := portainer.NewClient(url, jwt)
+		if password == "" {
+			return fmt.Errorf("password cannot be empty")
+		}
+
+		fmt.Print("Authenticating... ")
+		jwt, err := getJWTFromPortainer(url, username, password)
+		if err != nil {
+			fmt.Println("✗")
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+		fmt.Println("✓")
+
+		loginCtx.JWT = jwt
+		loginCtx.Username = username
+		client = portainer.NewClient(url, jwt)
+		fmt.Print("Validating credentials... ")
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -90,20 +147,60 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		fmt.Println("✗")
 		return fmt.Errorf("validation failed: %w", err)
 	}
+	fmt.Println("✓")
+
+	// Keep the default endpoint when re-authenticating against the same instance
+	if existing, ok := cfg.Contexts[name]; ok && existing.PortainerURL == url {
+		loginCtx.Endpoint = existing.Endpoint
+	}
 
-	cfg := &config.Config{
-		PortainerURL: url,
-		JWT:          jwt,
+	cfg.Contexts[name] = loginCtx
+	if cfg.CurrentContext == "" {
+		cfg.CurrentContext = name
 	}
 
 	if err := config.Save(cfg); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 
-	fmt.Println("✓ Login successful. Config saved to ~/.remdoc/config.json")
+	fmt.Printf("✓ Login successful. Credentials saved to context %s in ~/.remdoc/config.json\n", name)
 	return nil
 }
 
+// readAPIKey returns the access token from REMDOC_API_KEY, prompting securely if unset
+func readAPIKey() (string, error) {
+	apiKey := strings.TrimSpace(os.Getenv(config.APIKeyEnv))
+	if apiKey == "" {
+		fmt.Print("Access token: ")
+		keyBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Println()
+		if err != nil {
+			return "", fmt.Errorf("failed to read access token: %w", err)
+		}
+		apiKey = strings.TrimSpace(string(keyBytes))
+	}
+
+	if apiKey == "" {
+		return "", fmt.Errorf("access token cannot be empty")
+	}
+
+	return apiKey, nil
+}
+
+// loginContextName picks the context that login should write to
+func loginContextName(cfg *config.Config) string {
+	if contextFlag != "" {
+		return contextFlag
+	}
+	if env := os.Getenv(config.ContextEnv); env != "" {
+		return env
+	}
+	if cfg.CurrentContext != "" {
+		return cfg.CurrentContext
+	}
+	return config.DefaultContext
+}
+
 func getJWTFromPortainer(baseURL, username, password string) (string, error) {
 	authURL := strings.TrimRight(baseURL, "/") + "/api/auth"
 
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logsFollow     bool
+	logsTail       string
+	logsSince      string
+	logsUntil      string
+	logsTimestamps bool
+)
+
+var logsCmd = &cobra.Command{
+	Use:   "logs <container>",
+	Short: "Show the logs of a container",
+	Long: `Fetch the logs of a container on the remote server.
+
+The container can be specified by ID or name. --since and --until accept a
+relative duration (e.g. 10m, 2h), an RFC 3339 timestamp or a Unix timestamp.
+
+Examples:
+  remdoc logs my-nginx
+  remdoc logs my-nginx --follow --tail 100
+  remdoc logs my-nginx --since 30m --timestamps
+  remdoc logs my-nginx --since 2024-05-01T10:00:00Z --until 2024-05-01T11:00:00Z`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLogs,
+}
+
+func init() {
+	logsCmd.Flags().BoolVarP(&logsFollow, "follow", "f", false, "Follow log output")
+	logsCmd.Flags().StringVarP(&logsTail, "tail", "n", "all", "Number of lines to show from the end of the logs")
+	logsCmd.Flags().StringVar(&logsSince, "since", "", "Show logs since a timestamp or relative duration (e.g. 10m)")
+	logsCmd.Flags().StringVar(&logsUntil, "until", "", "Show logs before a timestamp or relative duration (e.g. 10m)")
+	logsCmd.Flags().BoolVarP(&logsTimestamps, "timestamps", "t", false, "Show timestamps")
+	rootCmd.AddCommand(logsCmd)
+}
+
+func runLogs(cmd *cobra.Command, args []string) error {
+	containerID := args[0]
+
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	if logsTail != "all" {
+		if n, err := strconv.Atoi(logsTail); err != nil || n < 0 {
+			return fmt.Errorf("--tail must be a non-negative number or \"all\" (got: %s)", logsTail)
+		}
+	}
+
+	since, err := parseTimeArg(logsSince)
+	if err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+
+	until, err := parseTimeArg(logsUntil)
+	if err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
+	}
+
+	opts := backend.LogsOptions{
+		Follow:     logsFollow,
+		Tail:       logsTail,
+		Since:      since,
+		Until:      until,
+		Timestamps: logsTimestamps,
+	}
+
+	// Following runs until interrupted; a one-off fetch gets a generous timeout
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if !logsFollow {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, 60*time.Second)
+		defer cancel()
+	}
+
+	if err := client.ContainerLogs(ctx, containerID, opts, os.Stdout, os.Stderr); err != nil {
+		return fmt.Errorf("failed to fetch logs: %w", err)
+	}
+
+	return nil
+}
+
+// parseTimeArg converts a relative duration, RFC 3339 timestamp or Unix
+// timestamp into the Unix timestamp string the Docker API expects
+func parseTimeArg(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value, nil
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		return strconv.FormatInt(time.Now().Add(-d).Unix(), 10), nil
+	}
+
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return strconv.FormatInt(t.Unix(), 10), nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return strconv.FormatInt(t.Unix(), 10), nil
+	}
+
+	return "", fmt.Errorf("expected a duration (e.g. 10m), RFC 3339 timestamp or Unix timestamp (got: %s)", value)
+}
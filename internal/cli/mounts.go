@@ -0,0 +1,262 @@
+package cli
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+)
+
+// bindOptions are the mode options accepted after the target in -v
+var bindOptions = map[string]bool{
+	"ro": true, "rw": true, "z": true, "Z": true, "nocopy": true,
+	"rprivate": true, "private": true, "rshared": true, "shared": true, "rslave": true, "slave": true,
+}
+
+// parseVolumes parses docker -v entries. Named volumes and host binds are
+// returned as binds; a bare container path becomes an anonymous volume mount.
+func parseVolumes(volumes []string) ([]string, []backend.Mount, error) {
+	var binds []string
+	var mounts []backend.Mount
+
+	for _, volume := range volumes {
+		parts := strings.Split(volume, ":")
+
+		switch len(parts) {
+		case 1:
+			target := parts[0]
+			if !path.IsAbs(target) {
+				return nil, nil, fmt.Errorf("%q: container path must be absolute", volume)
+			}
+			mounts = append(mounts, backend.Mount{Type: "volume", Target: target})
+			continue
+		case 2, 3:
+		default:
+			return nil, nil, fmt.Errorf("%q: must be in format SOURCE:TARGET[:OPTIONS] or TARGET", volume)
+		}
+
+		source, target := parts[0], parts[1]
+		if source == "" {
+			return nil, nil, fmt.Errorf("%q: source cannot be empty", volume)
+		}
+		if !path.IsAbs(target) {
+			return nil, nil, fmt.Errorf("%q: container path must be absolute", volume)
+		}
+
+		// Host paths must be absolute; anything else is a volume name
+		if !strings.HasPrefix(source, "/") && strings.ContainsAny(source, `/\`) {
+			return nil, nil, fmt.Errorf("%q: host path must be absolute", volume)
+		}
+
+		if len(parts) == 3 {
+			modes := strings.Split(parts[2], ",")
+			access := 0
+			for _, mode := range modes {
+				if !bindOptions[mode] {
+					return nil, nil, fmt.Errorf("%q: unknown option %q (use ro, rw, z, Z, nocopy or a propagation mode)", volume, mode)
+				}
+				if mode == "ro" || mode == "rw" {
+					access++
+				}
+			}
+			if access > 1 {
+				return nil, nil, fmt.Errorf("%q: ro and rw are mutually exclusive", volume)
+			}
+		}
+
+		binds = append(binds, volume)
+	}
+
+	return binds, mounts, nil
+}
+
+// parseMounts parses docker --mount entries such as
+// type=bind,source=/srv/data,target=/data,readonly
+func parseMounts(specs []string) ([]backend.Mount, error) {
+	var mounts []backend.Mount
+
+	for _, spec := range specs {
+		mount, err := parseMount(spec)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", spec, err)
+		}
+		mounts = append(mounts, mount)
+	}
+
+	return mounts, nil
+}
+
+func parseMount(spec string) (backend.Mount, error) {
+	mount := backend.Mount{Type: "volume"}
+
+	for _, field := range strings.Split(spec, ",") {
+		key, value, hasValue := strings.Cut(strings.TrimSpace(field), "=")
+		key = strings.ToLower(key)
+
+		switch key {
+		case "type":
+			mount.Type = value
+		case "source", "src":
+			mount.Source = value
+		case "target", "destination", "dst":
+			mount.Target = value
+		case "readonly", "ro":
+			readOnly := true
+			if hasValue {
+				parsed, err := strconv.ParseBool(value)
+				if err != nil {
+					return mount, fmt.Errorf("invalid value for %s: %s", key, value)
+				}
+				readOnly = parsed
+			}
+			mount.ReadOnly = readOnly
+		case "bind-propagation":
+			mount.BindPropagation = value
+		case "volume-nocopy":
+			noCopy := true
+			if hasValue {
+				parsed, err := strconv.ParseBool(value)
+				if err != nil {
+					return mount, fmt.Errorf("invalid value for %s: %s", key, value)
+				}
+				noCopy = parsed
+			}
+			mount.VolumeNoCopy = noCopy
+		case "volume-driver":
+			mount.VolumeDriver = value
+		case "volume-opt":
+			optKey, optValue, ok := strings.Cut(value, "=")
+			if !ok {
+				return mount, fmt.Errorf("volume-opt must be in format volume-opt=KEY=VALUE")
+			}
+			if mount.VolumeOptions == nil {
+				mount.VolumeOptions = make(map[string]string)
+			}
+			mount.VolumeOptions[optKey] = optValue
+		case "volume-label":
+			labelKey, labelValue, _ := strings.Cut(value, "=")
+			if mount.VolumeLabels == nil {
+				mount.VolumeLabels = make(map[string]string)
+			}
+			mount.VolumeLabels[labelKey] = labelValue
+		case "tmpfs-size":
+			size, err := parseByteSize(value)
+			if err != nil {
+				return mount, fmt.Errorf("invalid tmpfs-size: %w", err)
+			}
+			mount.TmpfsSize = size
+		case "tmpfs-mode":
+			mode, err := strconv.ParseUint(value, 8, 32)
+			if err != nil {
+				return mount, fmt.Errorf("invalid tmpfs-mode %q (expected octal, e.g. 1777)", value)
+			}
+			mount.TmpfsMode = uint32(mode)
+		default:
+			return mount, fmt.Errorf("unknown option %q", key)
+		}
+
+		if !hasValue && key != "readonly" && key != "ro" && key != "volume-nocopy" {
+			return mount, fmt.Errorf("option %q requires a value", key)
+		}
+	}
+
+	switch mount.Type {
+	case "volume":
+	case "bind":
+		if mount.Source == "" {
+			return mount, fmt.Errorf("bind mounts require a source")
+		}
+		if !path.IsAbs(mount.Source) {
+			return mount, fmt.Errorf("bind source must be an absolute path")
+		}
+	case "tmpfs":
+		if mount.Source != "" {
+			return mount, fmt.Errorf("tmpfs mounts do not take a source")
+		}
+	default:
+		return mount, fmt.Errorf("unsupported mount type %q (use volume, bind or tmpfs)", mount.Type)
+	}
+
+	if mount.Target == "" {
+		return mount, fmt.Errorf("target is required")
+	}
+	if !path.IsAbs(mount.Target) {
+		return mount, fmt.Errorf("target must be an absolute path")
+	}
+
+	if mount.Type != "bind" && mount.BindPropagation != "" {
+		return mount, fmt.Errorf("bind-propagation only applies to bind mounts")
+	}
+	if mount.Type != "volume" && (mount.VolumeDriver != "" || mount.VolumeOptions != nil || mount.VolumeLabels != nil || mount.VolumeNoCopy) {
+		return mount, fmt.Errorf("volume-* options only apply to volume mounts")
+	}
+	if mount.Type != "tmpfs" && (mount.TmpfsSize != 0 || mount.TmpfsMode != 0) {
+		return mount, fmt.Errorf("tmpfs-* options only apply to tmpfs mounts")
+	}
+
+	return mount, nil
+}
+
+// parseByteSize parses sizes like 512, 64k, 1.5g or 2t into bytes. Like
+// docker, units are binary and a trailing "b" or "ib" is allowed.
+func parseByteSize(value string) (int64, error) {
+	number := strings.TrimSpace(strings.ToLower(value))
+	number = strings.TrimSuffix(strings.TrimSuffix(number, "b"), "i")
+
+	multiplier := int64(1)
+	if number != "" {
+		switch number[len(number)-1] {
+		case 'k':
+			multiplier = 1 << 10
+		case 'm':
+			multiplier = 1 << 20
+		case 'g':
+			multiplier = 1 << 30
+		case 't':
+			multiplier = 1 << 40
+		case 'p':
+			multiplier = 1 << 50
+		}
+		if multiplier != 1 {
+			number = strings.TrimSpace(number[:len(number)-1])
+		}
+	}
+
+	whole, fraction, isDecimal := strings.Cut(number, ".")
+	if !isDigits(whole) || (isDecimal && !isDigits(fraction)) {
+		return 0, fmt.Errorf("expected a size such as 512, 64k, 1.5g or 2t")
+	}
+
+	if !isDecimal {
+		n, err := strconv.ParseInt(whole, 10, 64)
+		if err != nil || n > math.MaxInt64/multiplier {
+			return 0, fmt.Errorf("size %s is too large", strings.TrimSpace(value))
+		}
+		return n * multiplier, nil
+	}
+
+	// Sizes are truncated to whole bytes; 1<<63 is the first float64 past
+	// the int64 range
+	size, _ := strconv.ParseFloat(number, 64)
+	size *= float64(multiplier)
+	if size >= 1<<63 {
+		return 0, fmt.Errorf("size %s is too large", strings.TrimSpace(value))
+	}
+	return int64(size), nil
+}
+
+// isDigits reports whether s is a non-empty run of ASCII digits
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr string
+	}{
+		{value: "0", want: 0},
+		{value: "512", want: 512},
+		{value: "512b", want: 512},
+		{value: "64k", want: 64 << 10},
+		{value: "64K", want: 64 << 10},
+		{value: "100m", want: 100 << 20},
+		{value: "100mb", want: 100 << 20},
+		{value: "100MiB", want: 100 << 20},
+		{value: "2g", want: 2 << 30},
+		{value: "2 g", want: 2 << 30},
+		{value: "1.5g", want: 3 << 29},
+		{value: "0.5k", want: 512},
+		{value: "1.0001k", want: 1024},
+		{value: "2t", want: 2 << 40},
+		{value: "1p", want: 1 << 50},
+		{value: " 8191p ", want: 8191 << 50},
+		{value: "9223372036854775807", want: 1<<63 - 1},
+
+		{value: "", wantErr: "expected a size"},
+		{value: "g", wantErr: "expected a size"},
+		{value: "ib", wantErr: "expected a size"},
+		{value: "-1m", wantErr: "expected a size"},
+		{value: "1.5.2g", wantErr: "expected a size"},
+		{value: ".5g", wantErr: "expected a size"},
+		{value: "5.g", wantErr: "expected a size"},
+		{value: "1e3", wantErr: "expected a size"},
+		{value: "12x", wantErr: "expected a size"},
+		{value: "8192p", wantErr: "too large"},
+		{value: "8192.0p", wantErr: "too large"},
+		{value: "9223372036854775808", wantErr: "too large"},
+		{value: "99999999999999999999k", wantErr: "too large"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseByteSize(tt.value)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseByteSize(%q) = %d, %v, want error %q", tt.value, got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseByteSize(%q) unexpected error: %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseByteSize(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,341 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+	"github.com/spf13/cobra"
+)
+
+var (
+	networkFilters    []string
+	networkDriver     string
+	networkInternal   bool
+	networkAttachable bool
+	networkSubnet     string
+	networkGateway    string
+	networkIPRange    string
+	networkLabels     []string
+	networkOpts       []string
+	networkAliases    []string
+	networkIP         string
+	networkForce      bool
+)
+
+var networkCmd = &cobra.Command{
+	Use:   "network",
+	Short: "Manage networks on the remote server",
+	Long: `List, create, inspect and remove Docker networks on the remote server, and
+connect containers to them.
+
+Containers on the same user-defined network can reach each other by name.
+
+Examples:
+  remdoc network create backend
+  remdoc deploy --image postgres:14 --name db --network backend
+  remdoc deploy --image myapp --name api --network backend --env DB_HOST=db
+  remdoc network connect backend my-nginx --alias web
+  remdoc network inspect backend`,
+}
+
+var networkLsCmd = &cobra.Command{
+	Use:     "ls",
+	Aliases: []string{"list"},
+	Short:   "List networks",
+	Args:    cobra.NoArgs,
+	RunE:    runNetworkLs,
+}
+
+var networkCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a network",
+	Long: `Create a network. The default driver is bridge.
+
+Examples:
+  remdoc network create backend
+  remdoc network create backend --subnet 172.28.0.0/16 --gateway 172.28.0.1
+  remdoc network create private --internal`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNetworkCreate,
+}
+
+var networkInspectCmd = &cobra.Command{
+	Use:   "inspect <network>...",
+	Short: "Show detailed information about networks",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runNetworkInspect,
+}
+
+var networkRmCmd = &cobra.Command{
+	Use:     "rm <network>...",
+	Aliases: []string{"remove"},
+	Short:   "Remove networks",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    runNetworkRm,
+}
+
+var networkConnectCmd = &cobra.Command{
+	Use:   "connect <network> <container>",
+	Short: "Connect a container to a network",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runNetworkConnect,
+}
+
+var networkDisconnectCmd = &cobra.Command{
+	Use:   "disconnect <network> <container>",
+	Short: "Disconnect a container from a network",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runNetworkDisconnect,
+}
+
+func init() {
+	networkLsCmd.Flags().StringArrayVar(&networkFilters, "filter", []string{}, "Filter networks (e.g. driver=bridge,name=backend,label=app=web,scope=local)")
+
+	networkCreateCmd.Flags().StringVarP(&networkDriver, "driver", "d", "bridge", "Network driver")
+	networkCreateCmd.Flags().BoolVar(&networkInternal, "internal", false, "Restrict external access to the network")
+	networkCreateCmd.Flags().BoolVar(&networkAttachable, "attachable", false, "Allow standalone containers to attach (overlay networks)")
+	networkCreateCmd.Flags().StringVar(&networkSubnet, "subnet", "", "Subnet in CIDR format (e.g., 172.28.0.0/16)")
+	networkCreateCmd.Flags().StringVar(&networkGateway, "gateway", "", "Gateway for the subnet")
+	networkCreateCmd.Flags().StringVar(&networkIPRange, "ip-range", "", "Allocate container IPs from a sub-range")
+	networkCreateCmd.Flags().StringArrayVarP(&networkLabels, "label", "l", []string{}, "Network label (KEY=VALUE, can be specified multiple times)")
+	networkCreateCmd.Flags().StringArrayVar(&networkOpts, "opt", []string{}, "Driver option (KEY=VALUE, can be specified multiple times)")
+
+	networkConnectCmd.Flags().StringArrayVar(&networkAliases, "alias", []string{}, "Add a DNS alias for the container on the network (can be specified multiple times)")
+	networkConnectCmd.Flags().StringVar(&networkIP, "ip", "", "Static IPv4 address for the container")
+
+	networkDisconnectCmd.Flags().BoolVarP(&networkForce, "force", "f", false, "Force the container to disconnect")
+
+	networkCmd.AddCommand(networkLsCmd, networkCreateCmd, networkInspectCmd, networkRmCmd, networkConnectCmd, networkDisconnectCmd)
+	rootCmd.AddCommand(networkCmd)
+}
+
+func runNetworkLs(cmd *cobra.Command, args []string) error {
+	filters, err := parseFilters(networkFilters, nil)
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	networks, err := client.ListNetworks(ctx, filters)
+	if err != nil {
+		return fmt.Errorf("failed to fetch networks: %w", err)
+	}
+
+	return printList(networks, func(n backend.Network) string { return n.Name }, func() {
+		if len(networks) == 0 {
+			fmt.Println("No networks found.")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		if outputFlag == outputWide {
+			fmt.Fprintln(w, "NETWORK ID\tNAME\tDRIVER\tSCOPE\tSUBNET\tINTERNAL\tCREATED")
+		} else {
+			fmt.Fprintln(w, "NETWORK ID\tNAME\tDRIVER\tSCOPE")
+		}
+
+		for _, n := range networks {
+			id := n.ID
+			if len(id) > 12 {
+				id = id[:12]
+			}
+
+			if outputFlag == outputWide {
+				subnets := make([]string, 0, len(n.Subnets))
+				for _, s := range n.Subnets {
+					subnets = append(subnets, s.Subnet)
+				}
+				subnet := strings.Join(subnets, ", ")
+				if subnet == "" {
+					subnet = "-"
+				}
+
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%t\t%s\n", id, n.Name, n.Driver, n.Scope, subnet, n.Internal,
+					n.Created.Local().Format("2006-01-02 15:04:05"))
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", id, n.Name, n.Driver, n.Scope)
+		}
+
+		w.Flush()
+	})
+}
+
+func runNetworkCreate(cmd *cobra.Command, args []string) error {
+	if (networkGateway != "" || networkIPRange != "") && networkSubnet == "" {
+		return fmt.Errorf("--gateway and --ip-range require --subnet")
+	}
+
+	labels, err := parseKeyValues(networkLabels)
+	if err != nil {
+		return fmt.Errorf("invalid label: %w", err)
+	}
+
+	driverOpts, err := parseKeyValues(networkOpts)
+	if err != nil {
+		return fmt.Errorf("invalid driver option: %w", err)
+	}
+
+	opts := backend.NetworkCreateOptions{
+		Name:       args[0],
+		Driver:     networkDriver,
+		Internal:   networkInternal,
+		Attachable: networkAttachable,
+		Subnet:     networkSubnet,
+		Gateway:    networkGateway,
+		IPRange:    networkIPRange,
+		Labels:     labels,
+		Options:    driverOpts,
+	}
+
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	network, err := client.CreateNetwork(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to create network: %w", err)
+	}
+
+	return printResult(network, network.Name, func() {
+		fmt.Printf("✓ Network %s created (driver: %s)\n", network.Name, network.Driver)
+		for _, s := range network.Subnets {
+			fmt.Printf("  Subnet: %s\n", s.Subnet)
+		}
+	})
+}
+
+func runNetworkInspect(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	networks := make([]backend.Network, 0, len(args))
+	for _, name := range args {
+		network, err := client.InspectNetwork(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to inspect network: %w", err)
+		}
+		networks = append(networks, *network)
+	}
+
+	// Like docker network inspect, the human-readable default is JSON
+	if !machineOutput() {
+		return writeJSON(os.Stdout, networks)
+	}
+
+	return printList(networks, func(n backend.Network) string { return n.Name }, nil)
+}
+
+func runNetworkRm(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var results []resourceResult
+	for _, name := range args {
+		progressf("Removing network %s...\n", name)
+
+		if err := client.RemoveNetwork(ctx, name); err != nil {
+			return fmt.Errorf("failed to remove network: %w", err)
+		}
+		results = append(results, resourceResult{Action: "remove", Kind: "network", Name: name, Status: "removed"})
+	}
+
+	return printList(results, func(r resourceResult) string { return r.Name }, func() {
+		fmt.Println("✓ Network(s) removed successfully")
+	})
+}
+
+func runNetworkConnect(cmd *cobra.Command, args []string) error {
+	network, containerID := args[0], args[1]
+
+	if err := validateIPv4(networkIP); err != nil {
+		return err
+	}
+
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	progressf("Connecting %s to network %s...\n", containerID, network)
+
+	opts := backend.NetworkEndpointOptions{
+		Aliases:     networkAliases,
+		IPv4Address: networkIP,
+	}
+	if err := client.ConnectNetwork(ctx, network, containerID, opts); err != nil {
+		return fmt.Errorf("failed to connect container: %w", err)
+	}
+
+	result := actionResult{Action: "connect", Container: containerID, Status: "connected"}
+	return printResult(result, containerID, func() {
+		fmt.Printf("✓ Container %s connected to %s\n", containerID, network)
+	})
+}
+
+func runNetworkDisconnect(cmd *cobra.Command, args []string) error {
+	network, containerID := args[0], args[1]
+
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	progressf("Disconnecting %s from network %s...\n", containerID, network)
+
+	if err := client.DisconnectNetwork(ctx, network, containerID, networkForce); err != nil {
+		return fmt.Errorf("failed to disconnect container: %w", err)
+	}
+
+	result := actionResult{Action: "disconnect", Container: containerID, Status: "disconnected"}
+	return printResult(result, containerID, func() {
+		fmt.Printf("✓ Container %s disconnected from %s\n", containerID, network)
+	})
+}
+
+// validateIPv4 checks a static IP flag; an empty value means "assigned by IPAM"
+func validateIPv4(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	ip := net.ParseIP(value)
+	if ip == nil || ip.To4() == nil {
+		return fmt.Errorf("invalid IPv4 address %q", value)
+	}
+
+	return nil
+}
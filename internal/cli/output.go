@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Output modes accepted by --output
+const (
+	outputTable = "table"
+	outputWide  = "wide"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+	outputName  = "name"
+)
+
+var (
+	outputFlag string
+	formatFlag string
+)
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", outputTable, "Output format (table, wide, json, yaml, name)")
+	rootCmd.PersistentFlags().StringVar(&formatFlag, "format", "", "Format output using a Go template")
+	rootCmd.PersistentPreRunE = validateOutput
+}
+
+// validateOutput checks --output and, for machine-readable modes, lets
+// Execute report errors as structured objects instead of cobra's prose
+func validateOutput(cmd *cobra.Command, args []string) error {
+	switch outputFlag {
+	case outputTable, outputWide, outputJSON, outputYAML, outputName:
+	default:
+		return fmt.Errorf("unsupported output format %q (use table, wide, json, yaml or name)", outputFlag)
+	}
+
+	if machineOutput() {
+		cmd.Root().SilenceErrors = true
+		cmd.Root().SilenceUsage = true
+	}
+	return nil
+}
+
+// machineOutput reports whether output is meant for scripts rather than people
+func machineOutput() bool {
+	return formatFlag != "" || (outputFlag != outputTable && outputFlag != outputWide)
+}
+
+// progressf prints human-oriented progress messages, which are suppressed
+// in machine-readable modes so stdout only carries the result
+func progressf(format string, args ...interface{}) {
+	if !machineOutput() {
+		fmt.Printf(format, args...)
+	}
+}
+
+// printResult writes a command's result in the selected output mode. name is
+// printed in name mode; human renders the table/wide view.
+func printResult(v interface{}, name string, human func()) error {
+	switch {
+	case formatFlag != "":
+		return writeTemplate(os.Stdout, formatFlag, v)
+	case outputFlag == outputJSON:
+		return writeJSON(os.Stdout, v)
+	case outputFlag == outputYAML:
+		return writeYAML(os.Stdout, v)
+	case outputFlag == outputName:
+		_, err := fmt.Println(name)
+		return err
+	default:
+		human()
+		return nil
+	}
+}
+
+// printList writes a list result. Templates are applied per item, like
+// docker's --format; names are printed one per line.
+func printList[T any](items []T, name func(T) string, human func()) error {
+	switch {
+	case formatFlag != "":
+		for _, item := range items {
+			if err := writeTemplate(os.Stdout, formatFlag, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case outputFlag == outputJSON:
+		return writeJSON(os.Stdout, nonNil(items))
+	case outputFlag == outputYAML:
+		return writeYAML(os.Stdout, nonNil(items))
+	case outputFlag == outputName:
+		for _, item := range items {
+			fmt.Println(name(item))
+		}
+		return nil
+	default:
+		human()
+		return nil
+	}
+}
+
+// nonNil makes empty lists encode as [] rather than null
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
+
+// actionResult is the structured result of a single-container action
+type actionResult struct {
+	Action    string `json:"action" yaml:"action"`
+	Container string `json:"container" yaml:"container"`
+	Status    string `json:"status" yaml:"status"`
+}
+
+// resourceResult is the structured result of an action on a volume, image
+// or network
+type resourceResult struct {
+	Action string `json:"action" yaml:"action"`
+	Kind   string `json:"kind" yaml:"kind"` // "volume", "image" or "network"
+	Name   string `json:"name" yaml:"name"`
+	Status string `json:"status" yaml:"status"`
+}
+
+// errorResult is how errors are reported in machine-readable modes
+type errorResult struct {
+	Error string `json:"error" yaml:"error"`
+}
+
+// printError writes err to stderr in the selected output mode
+func printError(err error) {
+	switch outputFlag {
+	case outputJSON:
+		writeJSON(os.Stderr, errorResult{Error: err.Error()})
+	case outputYAML:
+		writeYAML(os.Stderr, errorResult{Error: err.Error()})
+	default:
+		fmt.Fprintln(os.Stderr, err)
+	}
+}
+
+// templateFuncs are available to --format templates, mirroring docker's
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join":  strings.Join,
+	"split": strings.Split,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"title": func(s string) string {
+		if s == "" {
+			return s
+		}
+		return strings.ToUpper(s[:1]) + s[1:]
+	},
+}
+
+// writeJSON writes v as indented JSON
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// writeJSONLine writes v as compact JSON on a single line, for streamed output
+func writeJSONLine(w io.Writer, v interface{}) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+// writeYAML writes v as YAML
+func writeYAML(w io.Writer, v interface{}) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// writeTemplate executes a Go template against v, adding a trailing newline
+func writeTemplate(w io.Writer, format string, v interface{}) error {
+	tmpl, err := template.New("format").Funcs(templateFuncs).Parse(format)
+	if err != nil {
+		return fmt.Errorf("invalid --format template: %w", err)
+	}
+
+	if err := tmpl.Execute(w, v); err != nil {
+		return fmt.Errorf("failed to execute --format template: %w", err)
+	}
+
+	_, err = fmt.Fprintln(w)
+	return err
+}
+
+// formatBytes renders a byte count with a binary unit suffix (e.g. 1.5GiB)
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
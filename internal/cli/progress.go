@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+	"github.com/Elias-Larsson/remdoc/internal/backend/portainer"
+	"golang.org/x/term"
+)
+
+// Pull policies accepted by deploy --pull
+const (
+	pullAlways  = "always"
+	pullMissing = "missing"
+	pullNever   = "never"
+)
+
+const progressBarWidth = 30
+
+// pullRenderer renders Docker's pull progress messages. On a terminal each
+// layer gets a line with a progress bar that is redrawn in place; otherwise
+// only status changes are printed, one per line.
+type pullRenderer struct {
+	out   io.Writer
+	tty   bool
+	order []string          // layer IDs in the order they first appeared
+	lines map[string]string // latest rendered line per layer
+	drawn int               // lines drawn by the last redraw
+}
+
+func newPullRenderer(out io.Writer, tty bool) *pullRenderer {
+	return &pullRenderer{out: out, tty: tty, lines: make(map[string]string)}
+}
+
+func (r *pullRenderer) update(p backend.PullProgress) {
+	// Messages without an ID (digest, final status) are printed below the layers
+	if p.ID == "" {
+		fmt.Fprintln(r.out, p.Status)
+		r.order, r.lines, r.drawn = nil, make(map[string]string), 0
+		return
+	}
+
+	line := formatPullLine(p, r.tty)
+
+	if !r.tty {
+		// Downloading/Extracting repeat for every chunk; print each status once
+		if r.lines[p.ID] == line {
+			return
+		}
+		r.lines[p.ID] = line
+		fmt.Fprintln(r.out, line)
+		return
+	}
+
+	if _, ok := r.lines[p.ID]; !ok {
+		r.order = append(r.order, p.ID)
+	}
+	r.lines[p.ID] = line
+
+	if r.drawn > 0 {
+		fmt.Fprintf(r.out, "\x1b[%dA", r.drawn)
+	}
+	for _, id := range r.order {
+		fmt.Fprintf(r.out, "\x1b[2K%s\n", r.lines[id])
+	}
+	r.drawn = len(r.order)
+}
+
+// formatPullLine renders a layer's status, with a progress bar on terminals
+func formatPullLine(p backend.PullProgress, bar bool) string {
+	line := fmt.Sprintf("%s: %s", p.ID, p.Status)
+	if !bar || p.Total <= 0 {
+		return line
+	}
+
+	current := p.Current
+	if current > p.Total {
+		current = p.Total
+	}
+
+	filled := int(int64(progressBarWidth) * current / p.Total)
+	progress := strings.Repeat("=", filled)
+	if filled < progressBarWidth {
+		progress += ">" + strings.Repeat(" ", progressBarWidth-filled-1)
+	}
+
+	return fmt.Sprintf("%s [%s] %s/%s", line, progress, formatBytes(uint64(current)), formatBytes(uint64(p.Total)))
+}
+
+// pullImage pulls an image, rendering progress unless output is for scripts
+func pullImage(ctx context.Context, client *portainer.Client, image string) error {
+	auth, err := resolveRegistryAuth(ctx, client, image)
+	if err != nil {
+		return err
+	}
+
+	progressf("Pulling image %s...\n", image)
+
+	var onProgress func(backend.PullProgress)
+	if !machineOutput() {
+		onProgress = newPullRenderer(os.Stdout, term.IsTerminal(int(os.Stdout.Fd()))).update
+	}
+
+	if err := client.PullImage(ctx, image, auth, onProgress); err != nil {
+		return fmt.Errorf("failed to pull image %s: %w", image, err)
+	}
+
+	return nil
+}
+
+// ensureImage applies a deploy --pull policy before creating a container
+func ensureImage(ctx context.Context, client *portainer.Client, image, policy string) error {
+	switch policy {
+	case pullNever:
+		return nil
+	case pullAlways:
+		return pullImage(ctx, client, image)
+	case pullMissing:
+		_, err := client.InspectImage(ctx, image)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, backend.ErrNotFound) {
+			return fmt.Errorf("failed to check for image %s: %w", image, err)
+		}
+		return pullImage(ctx, client, image)
+	default:
+		return fmt.Errorf("unsupported pull policy %q (use always, missing or never)", policy)
+	}
+}
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+	"github.com/Elias-Larsson/remdoc/internal/backend/portainer"
+	"github.com/Elias-Larsson/remdoc/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// Registry credential flags, shared by commands that pull images
+var (
+	registryRef      string
+	registryUsername string
+	registryPassword string
+)
+
+var registryCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Manage registry access for image pulls",
+	Long: `Show the registries configured in Portainer.
+
+Image pulls (image pull, deploy) pick credentials in this order:
+  1. --registry: a registry configured in Portainer, which supplies the credentials
+  2. --registry-username and --registry-password (or REMDOC_REGISTRY_PASSWORD)
+  3. Local Docker credentials from ~/.docker/config.json, including
+     credential helpers (credsStore and credHelpers)
+
+Examples:
+  remdoc registry ls
+  remdoc image pull registry.example.com/team/app:1.4 --registry company
+  remdoc deploy --image ghcr.io/acme/api:2 --registry-username bot --registry-password "$TOKEN"`,
+}
+
+var registryLsCmd = &cobra.Command{
+	Use:     "ls",
+	Aliases: []string{"list"},
+	Short:   "List registries configured in Portainer",
+	Args:    cobra.NoArgs,
+	RunE:    runRegistryLs,
+}
+
+func init() {
+	registryCmd.AddCommand(registryLsCmd)
+	rootCmd.AddCommand(registryCmd)
+}
+
+// addRegistryFlags registers the registry credential flags on a command
+func addRegistryFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&registryRef, "registry", "", "Pull using a registry configured in Portainer (ID, name or URL)")
+	cmd.Flags().StringVar(&registryUsername, "registry-username", "", "Registry username (instead of ~/.docker/config.json)")
+	cmd.Flags().StringVar(&registryPassword, "registry-password", "", "Registry password or access token (or set "+config.RegistryPasswordEnv+")")
+	cmd.MarkFlagsMutuallyExclusive("registry", "registry-username")
+	cmd.MarkFlagsMutuallyExclusive("registry", "registry-password")
+}
+
+func runRegistryLs(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	registries, err := client.ListRegistries(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch registries: %w", err)
+	}
+
+	rows := make([]registryRow, len(registries))
+	for i, r := range registries {
+		rows[i] = registryRow{
+			ID:             r.ID,
+			Name:           r.Name,
+			Type:           r.TypeName(),
+			URL:            r.URL,
+			Authentication: r.Authentication,
+			Username:       r.Username,
+		}
+	}
+
+	return printList(rows, func(r registryRow) string { return r.Name }, func() {
+		if len(rows) == 0 {
+			fmt.Println("No registries found.")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tTYPE\tURL\tAUTH")
+
+		for _, r := range rows {
+			auth := "-"
+			if r.Authentication {
+				auth = r.Username
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", r.ID, r.Name, r.Type, r.URL, auth)
+		}
+
+		w.Flush()
+	})
+}
+
+// registryRow is the printable form of a Portainer registry
+type registryRow struct {
+	ID             int    `json:"id" yaml:"id"`
+	Name           string `json:"name" yaml:"name"`
+	Type           string `json:"type" yaml:"type"`
+	URL            string `json:"url" yaml:"url"`
+	Authentication bool   `json:"authentication" yaml:"authentication"`
+	Username       string `json:"username,omitempty" yaml:"username,omitempty"`
+}
+
+// resolveRegistryAuth picks the credentials for pulling image from the
+// registry flags or the local Docker config. It returns nil for anonymous pulls.
+func resolveRegistryAuth(ctx context.Context, client *portainer.Client, image string) (*backend.RegistryAuth, error) {
+	if registryRef != "" {
+		registry, err := client.FindRegistry(ctx, registryRef)
+		if err != nil {
+			return nil, err
+		}
+		return &backend.RegistryAuth{RegistryID: registry.ID}, nil
+	}
+
+	host := registryHost(image)
+
+	password := registryPassword
+	if password == "" {
+		password = os.Getenv(config.RegistryPasswordEnv)
+	}
+
+	if registryUsername != "" {
+		if password == "" {
+			return nil, fmt.Errorf("--registry-username requires --registry-password or %s", config.RegistryPasswordEnv)
+		}
+		return &backend.RegistryAuth{
+			Username:      registryUsername,
+			Password:      password,
+			ServerAddress: registryServer(host),
+		}, nil
+	}
+	if registryPassword != "" {
+		return nil, fmt.Errorf("--registry-password requires --registry-username")
+	}
+
+	auth, err := dockerCredentials(host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load credentials for %s: %w", host, err)
+	}
+
+	return auth, nil
+}
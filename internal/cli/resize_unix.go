@@ -0,0 +1,49 @@
+//go:build !windows
+
+package cli
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+	"golang.org/x/term"
+)
+
+// watchTerminalSize sends the current terminal size, then every change
+// signalled by SIGWINCH, until ctx is cancelled
+func watchTerminalSize(ctx context.Context, fd int) <-chan backend.TerminalSize {
+	sizes := make(chan backend.TerminalSize, 1)
+	if !term.IsTerminal(fd) {
+		close(sizes)
+		return sizes
+	}
+
+	winch := make(chan os.Signal, 1)
+	signal.Notify(winch, syscall.SIGWINCH)
+
+	go func() {
+		defer close(sizes)
+		defer signal.Stop(winch)
+
+		for {
+			if width, height, err := term.GetSize(fd); err == nil {
+				select {
+				case sizes <- backend.TerminalSize{Width: uint(width), Height: uint(height)}:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-winch:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return sizes
+}
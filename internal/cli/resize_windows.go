@@ -0,0 +1,51 @@
+//go:build windows
+
+package cli
+
+import (
+	"context"
+	"time"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+	"golang.org/x/term"
+)
+
+// watchTerminalSize sends the current terminal size and then polls for
+// changes, since Windows consoles have no SIGWINCH
+func watchTerminalSize(ctx context.Context, fd int) <-chan backend.TerminalSize {
+	sizes := make(chan backend.TerminalSize, 1)
+	if !term.IsTerminal(fd) {
+		close(sizes)
+		return sizes
+	}
+
+	go func() {
+		defer close(sizes)
+
+		ticker := time.NewTicker(250 * time.Millisecond)
+		defer ticker.Stop()
+
+		var last backend.TerminalSize
+		for {
+			if width, height, err := term.GetSize(fd); err == nil {
+				current := backend.TerminalSize{Width: uint(width), Height: uint(height)}
+				if current != last {
+					last = current
+					select {
+					case sizes <- current:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return sizes
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+)
+
+// ulimitNames are the resource names accepted by --ulimit
+var ulimitNames = map[string]bool{
+	"core": true, "cpu": true, "data": true, "fsize": true, "locks": true, "memlock": true, "msgqueue": true,
+	"nice": true, "nofile": true, "nproc": true, "rss": true, "rtprio": true, "rttime": true, "sigpending": true, "stack": true,
+}
+
+// parseCPUs converts a --cpus value such as 1.5 into nano CPUs
+func parseCPUs(cpus float64) (int64, error) {
+	if cpus < 0 || math.IsNaN(cpus) || math.IsInf(cpus, 0) {
+		return 0, fmt.Errorf("invalid --cpus %v (must be a positive number, e.g. 0.5 or 2)", cpus)
+	}
+
+	nano := math.Round(cpus * 1e9)
+	if nano > math.MaxInt64 {
+		return 0, fmt.Errorf("--cpus %v is too large", cpus)
+	}
+
+	return int64(nano), nil
+}
+
+// parseUlimits parses --ulimit entries in the form NAME=SOFT[:HARD]. A
+// missing hard limit equals the soft one; -1 means unlimited. Later entries
+// for the same name replace earlier ones.
+func parseUlimits(values []string) ([]backend.Ulimit, error) {
+	var ulimits []backend.Ulimit
+	index := make(map[string]int)
+
+	for _, value := range values {
+		ulimit, err := parseUlimit(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", value, err)
+		}
+
+		if i, ok := index[ulimit.Name]; ok {
+			ulimits[i] = ulimit
+			continue
+		}
+		index[ulimit.Name] = len(ulimits)
+		ulimits = append(ulimits, ulimit)
+	}
+
+	return ulimits, nil
+}
+
+func parseUlimit(value string) (backend.Ulimit, error) {
+	name, limits, ok := strings.Cut(value, "=")
+	if !ok {
+		return backend.Ulimit{}, fmt.Errorf("must be in format NAME=SOFT[:HARD]")
+	}
+	if !ulimitNames[name] {
+		return backend.Ulimit{}, fmt.Errorf("unknown ulimit %q", name)
+	}
+
+	softStr, hardStr, hasHard := strings.Cut(limits, ":")
+
+	soft, err := parseUlimitValue(softStr)
+	if err != nil {
+		return backend.Ulimit{}, err
+	}
+
+	hard := soft
+	if hasHard {
+		hard, err = parseUlimitValue(hardStr)
+		if err != nil {
+			return backend.Ulimit{}, err
+		}
+	}
+
+	if hard != -1 && (soft == -1 || soft > hard) {
+		return backend.Ulimit{}, fmt.Errorf("soft limit %s is greater than hard limit %d", softStr, hard)
+	}
+
+	return backend.Ulimit{Name: name, Soft: soft, Hard: hard}, nil
+}
+
+func parseUlimitValue(value string) (int64, error) {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < -1 {
+		return 0, fmt.Errorf("invalid limit %q (expected a number, or -1 for unlimited)", value)
+	}
+	return n, nil
+}
+
+// normalizeCapabilities upper-cases capability names so "net_admin" and
+// "NET_ADMIN" are treated the same
+func normalizeCapabilities(caps []string) []string {
+	var normalized []string
+	for _, c := range caps {
+		if c = strings.ToUpper(strings.TrimSpace(c)); c != "" {
+			normalized = append(normalized, c)
+		}
+	}
+	return normalized
+}
+
+// parseSecurityOpts checks --security-opt entries. Like docker, a seccomp
+// profile given as a file path is read locally and sent inline, since the
+// remote host can't see local files.
+func parseSecurityOpts(opts []string) ([]string, error) {
+	var result []string
+
+	for _, opt := range opts {
+		key, value, hasValue := strings.Cut(opt, "=")
+		if key == "" || (!hasValue && key != "no-new-privileges") {
+			return nil, fmt.Errorf("invalid security option %q (use KEY=VALUE or no-new-privileges)", opt)
+		}
+
+		if key == "seccomp" && value != "unconfined" && value != "builtin" {
+			profile, err := os.ReadFile(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read seccomp profile: %w", err)
+			}
+
+			var compact bytes.Buffer
+			if err := json.Compact(&compact, profile); err != nil {
+				return nil, fmt.Errorf("invalid seccomp profile %s: %w", value, err)
+			}
+			opt = "seccomp=" + compact.String()
+		}
+
+		result = append(result, opt)
+	}
+
+	return result, nil
+}
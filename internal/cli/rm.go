@@ -38,7 +38,7 @@ func runRm(cmd *cobra.Command, args []string) error {
         return err
     }
 
-    fmt.Printf("Removing container %s...\n", containerID)
+    progressf("Removing container %s...\n", containerID)
 
     ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
     defer cancel()
@@ -47,6 +47,8 @@ func runRm(cmd *cobra.Command, args []string) error {
         return fmt.Errorf("failed to remove container: %w", err)
     }
 
-    fmt.Println("✓ Container removed successfully")
-    return nil
+    result := actionResult{Action: "remove", Container: containerID, Status: "removed"}
+    return printResult(result, containerID, func() {
+        fmt.Println("✓ Container removed successfully")
+    })
 }
\ No newline at end of file
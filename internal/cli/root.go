@@ -1,12 +1,16 @@
 package cli
 
 import (
+    "bufio"
+    "errors"
     "fmt"
     "os"
+    "strings"
 
     "github.com/Elias-Larsson/remdoc/internal/backend/portainer"
     "github.com/Elias-Larsson/remdoc/internal/config"
     "github.com/spf13/cobra"
+    "golang.org/x/term"
 )
 
 var rootCmd = &cobra.Command{
@@ -16,18 +20,132 @@ var rootCmd = &cobra.Command{
 on remote servers using the Portainer API.`,
 }
 
+var (
+    endpointFlag string
+    contextFlag  string
+)
+
+func init() {
+    rootCmd.PersistentFlags().StringVar(&endpointFlag, "endpoint", "", "Portainer endpoint ID or name (overrides the configured default)")
+    rootCmd.PersistentFlags().StringVar(&contextFlag, "context", "", "Config context to use (overrides "+config.ContextEnv+" and the current context)")
+}
+
+// exitError carries a remote command's exit code out of a command
+type exitError struct {
+    code int
+}
+
+func (e *exitError) Error() string {
+    return fmt.Sprintf("exit status %d", e.code)
+}
+
 func Execute() {
     if err := rootCmd.Execute(); err != nil {
-        fmt.Fprintln(os.Stderr, err)
+        var exitErr *exitError
+        if errors.As(err, &exitErr) {
+            os.Exit(exitErr.code)
+        }
+        printError(err)
         os.Exit(1)
     }
 }
 
-// getClient loads config and returns a configured Portainer client
-func getClient() (*portainer.Client, error) {
+// loadContext loads config and returns it along with the active context
+func loadContext() (*config.Config, string, *config.Context, error) {
     cfg, err := config.Load()
+    if err != nil {
+        return nil, "", nil, err
+    }
+
+    name, ctx, err := cfg.Active(contextFlag)
+    if err != nil {
+        return nil, "", nil, err
+    }
+
+    return cfg, name, ctx, nil
+}
+
+// getClient loads config and returns a Portainer client for the active
+// context, or for REMDOC_URL and REMDOC_API_KEY when both are set
+func getClient() (*portainer.Client, error) {
+    url, err := envURL()
+    if err != nil {
+        return nil, err
+    }
+    if url != "" {
+        client := portainer.NewAPIKeyClient(url, strings.TrimSpace(os.Getenv(config.APIKeyEnv)))
+        client.Endpoint = endpointFlag
+        return client, nil
+    }
+
+    cfg, name, ctx, err := loadContext()
     if err != nil {
         return nil, err
     }
-    return portainer.NewClient(cfg.PortainerURL, cfg.JWT), nil
+
+    // An access token from the environment wins over stored credentials (for CI)
+    if apiKey := strings.TrimSpace(os.Getenv(config.APIKeyEnv)); apiKey != "" {
+        client := portainer.NewAPIKeyClient(ctx.PortainerURL, apiKey)
+        client.Endpoint = selectedEndpoint(ctx)
+        return client, nil
+    }
+
+    if !ctx.HasCredentials() {
+        return nil, fmt.Errorf("context %s has no credentials (run 'remdoc login --context %s' first)", name, name)
+    }
+
+    client := portainer.NewClient(ctx.PortainerURL, ctx.JWT)
+    client.APIKey = ctx.APIKey
+    client.Endpoint = selectedEndpoint(ctx)
+
+    if client.AuthMode() == portainer.AuthModeJWT {
+        client.Reauthenticate = reauthenticator(cfg, name, ctx)
+        if err := refreshExpiredToken(client); err != nil {
+            return nil, err
+        }
+        warnTokenExpiry(client.JWT)
+    }
+
+    return client, nil
+}
+
+// envURL returns REMDOC_URL, which configures a client without a stored
+// context. It requires REMDOC_API_KEY, so stored credentials are never sent
+// to a URL other than their context's.
+func envURL() (string, error) {
+    url := strings.TrimSpace(os.Getenv(config.URLEnv))
+    if url != "" && strings.TrimSpace(os.Getenv(config.APIKeyEnv)) == "" {
+        return "", fmt.Errorf("%s requires %s to be set as well", config.URLEnv, config.APIKeyEnv)
+    }
+    return url, nil
+}
+
+// confirm asks a yes/no question on stderr, defaulting to no. It refuses to
+// prompt when stdin is not a terminal or output is machine-readable, where
+// the answer can't come from a person; callers skip it with --force.
+func confirm(question string) (bool, error) {
+    if !term.IsTerminal(int(os.Stdin.Fd())) {
+        return false, fmt.Errorf("cannot ask for confirmation: stdin is not a terminal (use --force to skip it)")
+    }
+    if machineOutput() {
+        return false, fmt.Errorf("cannot ask for confirmation with machine-readable output (use --force to skip it)")
+    }
+
+    fmt.Fprintf(os.Stderr, "%s [y/N]: ", question)
+
+    answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+    if err != nil {
+        return false, nil
+    }
+
+    answer = strings.ToLower(strings.TrimSpace(answer))
+    return answer == "y" || answer == "yes", nil
+}
+
+// selectedEndpoint returns the --endpoint flag if set, otherwise the context default
+func selectedEndpoint(ctx *config.Context) string {
+    if endpointFlag != "" {
+        return endpointFlag
+    }
+    return ctx.Endpoint
 }
\ No newline at end of file
@@ -0,0 +1,354 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+	"github.com/spf13/cobra"
+)
+
+var (
+	stackFile  string
+	stackPrune bool
+	stackPull  bool
+	stackRef   string
+)
+
+// stackTimeout bounds stack operations; Portainer runs compose synchronously
+const stackTimeout = 5 * time.Minute
+
+var stackCmd = &cobra.Command{
+	Use:   "stack",
+	Short: "Manage compose stacks",
+	Long: `List, inspect, update, stop, start and remove compose stacks deployed
+through Portainer. Stacks are referenced by ID or name.
+
+Examples:
+  remdoc stack ls
+  remdoc stack inspect my-stack
+  remdoc stack update my-stack -f ./docker-compose.yml --prune
+  remdoc stack redeploy my-stack --pull
+  remdoc stack pull my-stack --ref release
+  remdoc stack stop my-stack
+  remdoc stack rm my-stack`,
+}
+
+var stackLsCmd = &cobra.Command{
+	Use:     "ls",
+	Aliases: []string{"list"},
+	Short:   "List stacks",
+	Args:    cobra.NoArgs,
+	RunE:    runStackLs,
+}
+
+var stackInspectCmd = &cobra.Command{
+	Use:   "inspect <stack>",
+	Short: "Show a stack's details, compose file and containers",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runStackInspect,
+}
+
+var stackUpdateCmd = &cobra.Command{
+	Use:   "update <stack>",
+	Short: "Update a stack with a new compose file",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runStackUpdate,
+}
+
+var stackRedeployCmd = &cobra.Command{
+	Use:   "redeploy <stack>",
+	Short: "Redeploy a stack with its current compose file",
+	Long: `Redeploy a stack with its current compose file and environment.
+
+Use --pull to pick up new versions of the images' tags.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStackRedeploy,
+}
+
+var stackPullCmd = &cobra.Command{
+	Use:   "pull <stack>",
+	Short: "Redeploy a Git-backed stack from its repository",
+	Long: `Fetch the latest commit of a Git-backed stack's branch and redeploy it.
+
+Use --ref to switch to another branch or reference. Credentials stored in
+Portainer are reused unless --git-username is given.
+
+Examples:
+  remdoc stack pull shop
+  remdoc stack pull shop --ref refs/tags/v1.4 --prune`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStackPull,
+}
+
+var stackStopCmd = &cobra.Command{
+	Use:   "stop <stack>...",
+	Short: "Stop stacks",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runStackStop,
+}
+
+var stackStartCmd = &cobra.Command{
+	Use:   "start <stack>...",
+	Short: "Start stopped stacks",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runStackStart,
+}
+
+var stackRmCmd = &cobra.Command{
+	Use:     "rm <stack>...",
+	Aliases: []string{"remove"},
+	Short:   "Tear down and remove stacks",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    runStackRm,
+}
+
+func init() {
+	stackUpdateCmd.Flags().StringVarP(&stackFile, "file", "f", "", "Path to the new docker-compose file (required)")
+	stackUpdateCmd.MarkFlagRequired("file")
+
+	stackPullCmd.Flags().StringVar(&stackRef, "ref", "", "Switch to another branch or reference (default: keep the current one)")
+	addGitAuthFlags(stackPullCmd)
+
+	for _, cmd := range []*cobra.Command{stackUpdateCmd, stackRedeployCmd, stackPullCmd} {
+		cmd.Flags().BoolVar(&stackPrune, "prune", false, "Remove services that are no longer in the compose file")
+		cmd.Flags().BoolVar(&stackPull, "pull", false, "Pull the latest images before redeploying")
+	}
+
+	stackCmd.AddCommand(stackLsCmd, stackInspectCmd, stackUpdateCmd, stackRedeployCmd, stackPullCmd, stackStopCmd, stackStartCmd, stackRmCmd)
+	rootCmd.AddCommand(stackCmd)
+}
+
+func runStackLs(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	stacks, err := client.ListStacks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch stacks: %w", err)
+	}
+
+	return printList(stacks, func(s backend.Stack) string { return s.Name }, func() {
+		if len(stacks) == 0 {
+			fmt.Println("No stacks found.")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		if outputFlag == outputWide {
+			fmt.Fprintln(w, "ID\tNAME\tTYPE\tSTATUS\tCREATED\tUPDATED\tUPDATED BY")
+		} else {
+			fmt.Fprintln(w, "ID\tNAME\tTYPE\tSTATUS\tCREATED")
+		}
+
+		for _, s := range stacks {
+			created := s.Created.Local().Format("2006-01-02 15:04:05")
+
+			if outputFlag == outputWide {
+				updated, updatedBy := "-", s.UpdatedBy
+				if !s.Updated.IsZero() {
+					updated = s.Updated.Local().Format("2006-01-02 15:04:05")
+				}
+				if updatedBy == "" {
+					updatedBy = "-"
+				}
+				fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", s.ID, s.Name, s.Type, s.Status, created, updated, updatedBy)
+				continue
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", s.ID, s.Name, s.Type, s.Status, created)
+		}
+
+		w.Flush()
+	})
+}
+
+// stackDetails is a stack together with the containers it runs
+type stackDetails struct {
+	backend.Stack `yaml:",inline"`
+	Containers    []backend.Container `json:"containers" yaml:"containers"`
+}
+
+func runStackInspect(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	stack, err := client.InspectStack(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to inspect stack: %w", err)
+	}
+
+	// Compose labels each container with its project, which is the stack name
+	containers, err := client.ListContainers(ctx, backend.ListOptions{
+		All:     true,
+		Filters: map[string][]string{"label": {"com.docker.compose.project=" + stack.Name}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch stack containers: %w", err)
+	}
+
+	details := stackDetails{Stack: *stack, Containers: nonNil(containers)}
+
+	// Like the other inspect commands, the human-readable default is JSON
+	if !machineOutput() {
+		return writeJSON(os.Stdout, details)
+	}
+
+	return printResult(details, stack.Name, nil)
+}
+
+func runStackUpdate(cmd *cobra.Command, args []string) error {
+	content, err := os.ReadFile(stackFile)
+	if err != nil {
+		return fmt.Errorf("failed to read compose file: %w", err)
+	}
+
+	opts := backend.StackUpdateOptions{
+		Content: string(content),
+		Prune:   stackPrune,
+		Pull:    stackPull,
+	}
+
+	return updateStack(args[0], opts, "update", "updated")
+}
+
+func runStackRedeploy(cmd *cobra.Command, args []string) error {
+	opts := backend.StackUpdateOptions{
+		Prune: stackPrune,
+		Pull:  stackPull,
+	}
+
+	return updateStack(args[0], opts, "redeploy", "redeployed")
+}
+
+// updateStack runs UpdateStack and reports the result
+func updateStack(ref string, opts backend.StackUpdateOptions, action, status string) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), stackTimeout)
+	defer cancel()
+
+	stack, err := client.InspectStack(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("failed to %s stack: %w", action, err)
+	}
+
+	progressf("Redeploying stack %s...\n", stack.Name)
+
+	if err := client.UpdateStack(ctx, strconv.Itoa(stack.ID), opts); err != nil {
+		return fmt.Errorf("failed to %s stack: %w", action, err)
+	}
+
+	result := stackResult{Action: action, Stack: stack.Name, ID: stack.ID, Status: status}
+	return printResult(result, stack.Name, func() {
+		fmt.Printf("✓ Stack %s %s (ID: %d)\n", stack.Name, status, stack.ID)
+	})
+}
+
+func runStackPull(cmd *cobra.Command, args []string) error {
+	username, password, err := gitCredentials()
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), stackTimeout)
+	defer cancel()
+
+	stack, err := client.InspectStack(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to pull stack: %w", err)
+	}
+
+	if stack.Git == nil {
+		return fmt.Errorf("stack %s is not deployed from Git (use 'remdoc stack redeploy')", stack.Name)
+	}
+
+	progressf("Pulling stack %s from %s...\n", stack.Name, stack.Git.URL)
+
+	opts := backend.StackPullOptions{
+		Ref:      stackRef,
+		Username: username,
+		Password: password,
+		Prune:    stackPrune,
+		Pull:     stackPull,
+	}
+	if err := client.PullStack(ctx, strconv.Itoa(stack.ID), opts); err != nil {
+		return fmt.Errorf("failed to pull stack: %w", err)
+	}
+
+	result := stackResult{Action: "pull", Stack: stack.Name, ID: stack.ID, Status: "updated"}
+	return printResult(result, stack.Name, func() {
+		fmt.Printf("✓ Stack %s updated from Git (ID: %d)\n", stack.Name, stack.ID)
+	})
+}
+
+func runStackStop(cmd *cobra.Command, args []string) error {
+	return stackActions(args, "stop", "Stopping", "stopped", func(ctx context.Context, client backend.Backend, ref string) error {
+		return client.StopStack(ctx, ref)
+	})
+}
+
+func runStackStart(cmd *cobra.Command, args []string) error {
+	return stackActions(args, "start", "Starting", "started", func(ctx context.Context, client backend.Backend, ref string) error {
+		return client.StartStack(ctx, ref)
+	})
+}
+
+func runStackRm(cmd *cobra.Command, args []string) error {
+	return stackActions(args, "remove", "Removing", "removed", func(ctx context.Context, client backend.Backend, ref string) error {
+		return client.RemoveStack(ctx, ref)
+	})
+}
+
+// stackActions applies a stop, start or remove to each stack in refs
+func stackActions(refs []string, action, progress, status string, apply func(context.Context, backend.Backend, string) error) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), stackTimeout)
+	defer cancel()
+
+	var results []stackResult
+	for _, ref := range refs {
+		stack, err := client.InspectStack(ctx, ref)
+		if err != nil {
+			return fmt.Errorf("failed to %s stack: %w", action, err)
+		}
+
+		progressf("%s stack %s...\n", progress, stack.Name)
+
+		if err := apply(ctx, client, strconv.Itoa(stack.ID)); err != nil {
+			return fmt.Errorf("failed to %s stack %s: %w", action, stack.Name, err)
+		}
+		results = append(results, stackResult{Action: action, Stack: stack.Name, ID: stack.ID, Status: status})
+	}
+
+	return printList(results, func(r stackResult) string { return r.Stack }, func() {
+		for _, r := range results {
+			fmt.Printf("✓ Stack %s %s\n", r.Stack, r.Status)
+		}
+	})
+}
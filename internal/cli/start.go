@@ -17,12 +17,14 @@ The container can be specified by ID or name.
 
 Examples:
   remdoc start my-nginx
-  remdoc start 186e01159dd1`,
+  remdoc start 186e01159dd1
+  remdoc start my-nginx --wait`,
     Args: cobra.ExactArgs(1),
     RunE: runStart,
 }
 
 func init() {
+    addWaitFlags(startCmd)
     rootCmd.AddCommand(startCmd)
 }
 
@@ -34,7 +36,7 @@ func runStart(cmd *cobra.Command, args []string) error {
         return err
     }
 
-    fmt.Printf("Starting container %s...\n", containerID)
+    progressf("Starting container %s...\n", containerID)
 
     ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
     defer cancel()
@@ -43,6 +45,20 @@ func runStart(cmd *cobra.Command, args []string) error {
         return fmt.Errorf("failed to start container: %w", err)
     }
 
-    fmt.Println("✓ Container started successfully")
-    return nil
+    result := actionResult{Action: "start", Container: containerID, Status: "started"}
+
+    if waitReady {
+        waitCtx, cancelWait := waitContext()
+        defer cancelWait()
+
+        status, err := waitForContainer(waitCtx, client, containerID)
+        if err != nil {
+            return err
+        }
+        result.Status = status
+    }
+
+    return printResult(result, containerID, func() {
+        fmt.Println("✓ Container started successfully")
+    })
 }
\ No newline at end of file
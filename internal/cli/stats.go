@@ -0,0 +1,272 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	statsAll      bool
+	statsNoStream bool
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats [container...]",
+	Short: "Show live resource usage of containers",
+	Long: `Show CPU, memory, network and block I/O usage of containers on the remote
+server. Without arguments, all running containers are shown.
+
+The table refreshes every second until interrupted. Use --no-stream for a
+single snapshot. With -o json, each sample is written as one JSON object per
+line while streaming, or as a JSON list with --no-stream.
+
+Examples:
+  remdoc stats
+  remdoc stats api worker
+  remdoc stats --no-stream -o json
+  remdoc stats -o json | jq -c '{name, cpuPercent}'`,
+	RunE: runStats,
+}
+
+func init() {
+	statsCmd.Flags().BoolVarP(&statsAll, "all", "a", false, "Show all containers (default shows just running)")
+	statsCmd.Flags().BoolVar(&statsNoStream, "no-stream", false, "Print a single snapshot instead of refreshing")
+	rootCmd.AddCommand(statsCmd)
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	if !statsNoStream && (outputFlag == outputYAML || outputFlag == outputName) {
+		return fmt.Errorf("-o %s is only supported with --no-stream", outputFlag)
+	}
+
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	targets := args
+	if len(targets) == 0 {
+		listCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+		containers, err := client.ListContainers(listCtx, backend.ListOptions{All: statsAll})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to fetch containers: %w", err)
+		}
+		for _, c := range containers {
+			targets = append(targets, c.Name)
+		}
+	}
+
+	if statsNoStream {
+		return statsSnapshot(ctx, client, targets)
+	}
+	return streamStats(ctx, client, targets)
+}
+
+// statsSnapshot fetches one sample per container and prints them together
+func statsSnapshot(ctx context.Context, client backend.Backend, targets []string) error {
+	// Docker waits for a second sample before answering, to compute CPU usage
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	samples := make([]backend.ContainerStats, len(targets))
+	errs := make([]error, len(targets))
+
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target string) {
+			defer wg.Done()
+			errs[i] = client.ContainerStats(ctx, target, false, func(s backend.ContainerStats) {
+				samples[i] = s
+			})
+		}(i, target)
+	}
+	wg.Wait()
+
+	var found []backend.ContainerStats
+	for i, err := range errs {
+		if errors.Is(err, backend.ErrNotFound) {
+			warnContainerGone(targets[i])
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to fetch stats for %s: %w", targets[i], err)
+		}
+		found = append(found, samples[i])
+	}
+
+	return printList(found, func(s backend.ContainerStats) string { return s.Name }, func() {
+		if len(found) == 0 {
+			fmt.Println(noStatsMessage())
+			return
+		}
+		writeStatsTable(os.Stdout, found)
+	})
+}
+
+// statsUpdate carries a sample, or the end of a container's stream, from the
+// per-container goroutines to the renderer
+type statsUpdate struct {
+	index int
+	stats backend.ContainerStats
+	done  bool
+	err   error
+}
+
+// streamStats follows the stats of every container until interrupted or all
+// streams end. Tables are redrawn once a second; machine-readable output is
+// written per sample.
+func streamStats(ctx context.Context, client backend.Backend, targets []string) error {
+	if len(targets) == 0 {
+		if !machineOutput() {
+			fmt.Println(noStatsMessage())
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	updates := make(chan statsUpdate)
+	send := func(u statsUpdate) {
+		select {
+		case updates <- u:
+		case <-ctx.Done():
+		}
+	}
+
+	for i, target := range targets {
+		go func(i int, target string) {
+			err := client.ContainerStats(ctx, target, true, func(s backend.ContainerStats) {
+				send(statsUpdate{index: i, stats: s})
+			})
+			send(statsUpdate{index: i, done: true, err: err})
+		}(i, target)
+	}
+
+	tty := term.IsTerminal(int(os.Stdout.Fd()))
+	latest := make([]*backend.ContainerStats, len(targets))
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for running := len(targets); running > 0; {
+		select {
+		case <-ctx.Done():
+			return nil
+		case u := <-updates:
+			if u.done {
+				// A container removed since it was listed just drops out
+				if errors.Is(u.err, backend.ErrNotFound) {
+					warnContainerGone(targets[u.index])
+					u.err = nil
+				}
+				if u.err != nil {
+					return fmt.Errorf("failed to fetch stats for %s: %w", targets[u.index], u.err)
+				}
+				// Stopped containers drop out of the table
+				latest[u.index] = nil
+				running--
+				continue
+			}
+
+			if machineOutput() {
+				if err := writeStatsSample(u.stats); err != nil {
+					return err
+				}
+				continue
+			}
+			sample := u.stats
+			latest[u.index] = &sample
+		case <-ticker.C:
+			if machineOutput() {
+				continue
+			}
+
+			var samples []backend.ContainerStats
+			for _, s := range latest {
+				if s != nil {
+					samples = append(samples, *s)
+				}
+			}
+
+			// Redraw in place on a terminal; otherwise append each refresh
+			if tty {
+				fmt.Print("\033[H\033[2J")
+			}
+			writeStatsTable(os.Stdout, samples)
+			if !tty {
+				fmt.Println()
+			}
+		}
+	}
+
+	// Every stream ended because its container stopped or was removed
+	if !machineOutput() {
+		fmt.Println(noStatsMessage())
+	}
+	return nil
+}
+
+// noStatsMessage is printed in table mode when there is nothing to show
+func noStatsMessage() string {
+	if statsAll {
+		return "No containers found."
+	}
+	return "No running containers."
+}
+
+// warnContainerGone notes a container that was removed before its stats
+// could be read
+func warnContainerGone(name string) {
+	fmt.Fprintf(os.Stderr, "Warning: container %s no longer exists\n", name)
+}
+
+// writeStatsSample writes one streamed sample as a JSON line or with --format
+func writeStatsSample(s backend.ContainerStats) error {
+	if formatFlag != "" {
+		return writeTemplate(os.Stdout, formatFlag, s)
+	}
+	return writeJSONLine(os.Stdout, s)
+}
+
+func writeStatsTable(out io.Writer, samples []backend.ContainerStats) {
+	w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	if outputFlag == outputWide {
+		fmt.Fprint(w, "CONTAINER ID\t")
+	}
+	fmt.Fprintln(w, "NAME\tCPU %\tMEM USAGE / LIMIT\tMEM %\tNET I/O\tBLOCK I/O\tPIDS")
+
+	for _, s := range samples {
+		if outputFlag == outputWide {
+			id := s.ID
+			if len(id) > 12 {
+				id = id[:12]
+			}
+			fmt.Fprintf(w, "%s\t", id)
+		}
+		fmt.Fprintf(w, "%s\t%.2f%%\t%s / %s\t%.2f%%\t%s / %s\t%s / %s\t%d\n",
+			s.Name, s.CPUPercent,
+			formatBytes(s.MemoryUsage), formatBytes(s.MemoryLimit), s.MemoryPercent,
+			formatBytes(s.NetworkRx), formatBytes(s.NetworkTx),
+			formatBytes(s.BlockRead), formatBytes(s.BlockWrite),
+			s.PIDs)
+	}
+
+	w.Flush()
+}
@@ -3,25 +3,91 @@ package cli
 import (
     "context"
     "fmt"
+    "net"
     "os"
+    "sort"
+    "strings"
     "text/tabwriter"
     "time"
 
+    "github.com/Elias-Larsson/remdoc/internal/backend"
     "github.com/spf13/cobra"
 )
 
+var (
+    statusFilters []string
+    statusRunning bool
+    statusSortBy  string
+    statusLimit   int
+)
+
+// filterKeys maps friendly --filter keys onto Docker filter names; other
+// keys are passed through for Docker to validate
+var filterKeys = map[string]string{
+    "state": "status",
+    "image": "ancestor",
+}
+
 var statusCmd = &cobra.Command{
     Use:   "status",
     Short: "List all containers on the remote server",
-    Long:  `Display the status of all Docker containers managed via Portainer.`,
-    RunE:  runStatus,
+    Long: `Display the status of all Docker containers managed via Portainer.
+
+Filters are evaluated by Docker on the remote host. Supported keys include
+name, state, image, label (key or key=value), id, network, volume and health;
+repeat a key to match any of its values. Filters may be comma-separated; a
+comma that isn't followed by KEY=VALUE stays part of the value, so
+--filter label=desc=a,b matches the label value "a,b".
+
+Examples:
+  remdoc status
+  remdoc status --running
+  remdoc status --filter name=web,state=exited
+  remdoc status --filter label=app=api --filter image=nginx
+  remdoc status --sort-by created --limit 10
+  remdoc status -o wide
+  remdoc status -o json
+  remdoc status --format '{{.Name}} {{.State}}'`,
+    RunE: runStatus,
 }
 
 func init() {
+    statusCmd.Flags().StringArrayVar(&statusFilters, "filter", []string{}, "Filter containers (e.g. name=web,state=running,image=nginx,label=k=v)")
+    statusCmd.Flags().BoolVar(&statusRunning, "running", false, "Only show running containers")
+    statusCmd.Flags().StringVar(&statusSortBy, "sort-by", "", "Sort by field (name, image, state, status, created (newest first), id)")
+    statusCmd.Flags().IntVar(&statusLimit, "limit", 0, "Show at most this many containers (0 = no limit)")
     rootCmd.AddCommand(statusCmd)
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
+    filters, err := parseFilters(statusFilters, filterKeys)
+    if err != nil {
+        return err
+    }
+
+    if statusLimit < 0 {
+        return fmt.Errorf("--limit cannot be negative")
+    }
+
+    less, err := containerSorter(statusSortBy)
+    if err != nil {
+        return err
+    }
+
+    opts := backend.ListOptions{
+        All:     !statusRunning,
+        Filters: filters,
+    }
+    if statusRunning {
+        opts.Filters["status"] = append(opts.Filters["status"], "running")
+    }
+
+    // Docker's limit returns the most recently created containers, which
+    // only matches the requested order when no other sort is applied
+    if statusSortBy == "" {
+        opts.Limit = statusLimit
+    }
+
     client, err := getClient()
     if err != nil {
         return err
@@ -30,23 +96,139 @@ func runStatus(cmd *cobra.Command, args []string) error {
     ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
     defer cancel()
 
-    containers, err := client.ListContainers(ctx)
+    containers, err := client.ListContainers(ctx, opts)
     if err != nil {
         return fmt.Errorf("failed to fetch containers: %w", err)
     }
 
-    if len(containers) == 0 {
-        fmt.Println("No containers found.")
-        return nil
+    if less != nil {
+        sort.SliceStable(containers, func(i, j int) bool { return less(containers[i], containers[j]) })
     }
+    if statusLimit > 0 && len(containers) > statusLimit {
+        containers = containers[:statusLimit]
+    }
+
+    return printList(containers, func(c backend.Container) string { return c.Name }, func() {
+        if len(containers) == 0 {
+            fmt.Println("No containers found.")
+            return
+        }
+
+        w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+        if outputFlag == outputWide {
+            fmt.Fprintln(w, "CONTAINER ID\tNAME\tIMAGE\tSTATE\tSTATUS\tPORTS\tCREATED")
+        } else {
+            fmt.Fprintln(w, "CONTAINER ID\tNAME\tIMAGE\tSTATE\tSTATUS")
+        }
 
-    w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-    fmt.Fprintln(w, "CONTAINER ID\tNAME\tIMAGE\tSTATE\tSTATUS")
+        for _, c := range containers {
+            if outputFlag == outputWide {
+                fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", c.ID, c.Name, c.Image, c.State, c.Status,
+                    formatPorts(c.Ports), c.Created.Local().Format("2006-01-02 15:04:05"))
+                continue
+            }
+            fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.ID, c.Name, c.Image, c.State, c.Status)
+        }
 
-    for _, c := range containers {
-        fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.ID, c.Name, c.Image, c.State, c.Status)
+        w.Flush()
+    })
+}
+
+// parseFilters turns KEY=VALUE filter flags into Docker's filter map,
+// renaming keys found in aliases. A flag may hold several comma-separated
+// filters; see splitFilters.
+func parseFilters(values []string, aliases map[string]string) (map[string][]string, error) {
+    filters := make(map[string][]string)
+
+    var entries []string
+    for _, value := range values {
+        entries = append(entries, splitFilters(value)...)
+    }
+
+    for _, value := range entries {
+        key, val, ok := strings.Cut(value, "=")
+        key = strings.TrimSpace(key)
+        if !ok || key == "" || val == "" {
+            return nil, fmt.Errorf("filter must be in format KEY=VALUE (got: %s)", value)
+        }
+
+        if alias, ok := aliases[key]; ok {
+            key = alias
+        }
+        filters[key] = append(filters[key], val)
     }
 
-    w.Flush()
-    return nil
-}
\ No newline at end of file
+    return filters, nil
+}
+
+// splitFilters splits a --filter value on commas, but only where the text
+// after the comma is itself a KEY=VALUE filter, so values containing commas
+// such as label=desc=a,b stay intact
+func splitFilters(value string) []string {
+    var entries []string
+
+    for _, segment := range strings.Split(value, ",") {
+        if len(entries) > 0 && !isFilterEntry(segment) {
+            entries[len(entries)-1] += "," + segment
+            continue
+        }
+        entries = append(entries, segment)
+    }
+
+    return entries
+}
+
+// isFilterEntry reports whether s starts with a filter key followed by "="
+func isFilterEntry(s string) bool {
+    key, _, ok := strings.Cut(s, "=")
+    key = strings.TrimSpace(key)
+    if !ok || key == "" {
+        return false
+    }
+
+    for _, r := range key {
+        if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-' || r == '.') {
+            return false
+        }
+    }
+    return true
+}
+
+// containerSorter returns the ordering for --sort-by, or nil for Docker's order
+func containerSorter(field string) (func(a, b backend.Container) bool, error) {
+    switch field {
+    case "":
+        return nil, nil
+    case "name":
+        return func(a, b backend.Container) bool { return a.Name < b.Name }, nil
+    case "image":
+        return func(a, b backend.Container) bool { return a.Image < b.Image }, nil
+    case "state":
+        return func(a, b backend.Container) bool { return a.State < b.State }, nil
+    case "status":
+        return func(a, b backend.Container) bool { return a.Status < b.Status }, nil
+    case "created":
+        return func(a, b backend.Container) bool { return a.Created.After(b.Created) }, nil
+    case "id":
+        return func(a, b backend.Container) bool { return a.ID < b.ID }, nil
+    default:
+        return nil, fmt.Errorf("unsupported --sort-by field %q (use name, image, state, status, created or id)", field)
+    }
+}
+
+// formatPorts renders port mappings like docker ps (e.g. 8080->80/tcp)
+func formatPorts(ports []backend.PortMapping) string {
+    parts := make([]string, 0, len(ports))
+    for _, p := range ports {
+        if p.HostPort == "" {
+            parts = append(parts, p.ContainerPort+"/"+p.Protocol)
+            continue
+        }
+        host := p.HostPort
+        if p.HostIP != "" {
+            host = net.JoinHostPort(p.HostIP, p.HostPort)
+        }
+        parts = append(parts, host+"->"+p.ContainerPort+"/"+p.Protocol)
+    }
+    return strings.Join(parts, ", ")
+}
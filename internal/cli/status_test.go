@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitFilters(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{value: "status=running", want: []string{"status=running"}},
+		{value: "status=running,name=web", want: []string{"status=running", "name=web"}},
+		{value: "label=desc=a,b", want: []string{"label=desc=a,b"}},
+		{value: "label=tags=a,b,name=web", want: []string{"label=tags=a,b", "name=web"}},
+		{value: "name=web,label=com.example.tier=front", want: []string{"name=web", "label=com.example.tier=front"}},
+		{value: "label=note=x, y=z", want: []string{"label=note=x", " y=z"}},
+		{value: "label=note=a b,c d=e", want: []string{"label=note=a b,c d=e"}},
+		{value: "name=web,", want: []string{"name=web,"}},
+		{value: ",name=web", want: []string{"", "name=web"}},
+		{value: "", want: []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := splitFilters(tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitFilters(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFilters(t *testing.T) {
+	aliases := map[string]string{"state": "status"}
+
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[string][]string
+		wantErr string
+	}{
+		{
+			name:   "repeated keys collect values",
+			values: []string{"status=running", "status=exited,name=web"},
+			want:   map[string][]string{"status": {"running", "exited"}, "name": {"web"}},
+		},
+		{
+			name:   "aliases are renamed",
+			values: []string{"state=running"},
+			want:   map[string][]string{"status": {"running"}},
+		},
+		{
+			name:   "commas stay in values",
+			values: []string{"label=desc=a,b"},
+			want:   map[string][]string{"label": {"desc=a,b"}},
+		},
+		{
+			name:   "key is trimmed",
+			values: []string{" name =web"},
+			want:   map[string][]string{"name": {"web"}},
+		},
+		{name: "missing value", values: []string{"status="}, wantErr: "KEY=VALUE"},
+		{name: "missing key", values: []string{"=running"}, wantErr: "KEY=VALUE"},
+		{name: "no separator", values: []string{"running"}, wantErr: "KEY=VALUE"},
+		{name: "leading comma", values: []string{",name=web"}, wantErr: "KEY=VALUE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFilters(tt.values, aliases)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseFilters(%q) error = %v, want %q", tt.values, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFilters(%q) unexpected error: %v", tt.values, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseFilters(%q) = %v, want %v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
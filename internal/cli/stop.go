@@ -34,7 +34,7 @@ func runStop(cmd *cobra.Command, args []string) error {
         return err
     }
 
-    fmt.Printf("Stopping container %s...\n", containerID)
+    progressf("Stopping container %s...\n", containerID)
 
     ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
     defer cancel()
@@ -43,6 +43,8 @@ func runStop(cmd *cobra.Command, args []string) error {
         return fmt.Errorf("failed to stop container: %w", err)
     }
 
-    fmt.Println("✓ Container stopped successfully")
-    return nil
+    result := actionResult{Action: "stop", Container: containerID, Status: "stopped"}
+    return printResult(result, containerID, func() {
+        fmt.Println("✓ Container stopped successfully")
+    })
 }
\ No newline at end of file
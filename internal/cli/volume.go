@@ -0,0 +1,298 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+	"github.com/spf13/cobra"
+)
+
+var (
+	volumeFilters      []string
+	volumeDriver       string
+	volumeOpts         []string
+	volumeLabels       []string
+	volumeRmForce      bool
+	volumePruneForce   bool
+	volumePruneAll     bool
+	volumePruneFilters []string
+)
+
+var volumeCmd = &cobra.Command{
+	Use:   "volume",
+	Short: "Manage volumes on the remote server",
+	Long: `List, create, inspect and remove Docker volumes on the remote server.
+
+Examples:
+  remdoc volume ls
+  remdoc volume ls --filter driver=local --filter label=app=db
+  remdoc volume create pgdata --label app=db
+  remdoc volume inspect pgdata
+  remdoc volume rm pgdata
+  remdoc volume prune --all`,
+}
+
+var volumeLsCmd = &cobra.Command{
+	Use:     "ls",
+	Aliases: []string{"list"},
+	Short:   "List volumes and the containers using them",
+	Args:    cobra.NoArgs,
+	RunE:    runVolumeLs,
+}
+
+var volumeCreateCmd = &cobra.Command{
+	Use:   "create [name]",
+	Short: "Create a volume",
+	Long: `Create a named volume. If no name is given, Docker generates one.
+
+Examples:
+  remdoc volume create pgdata
+  remdoc volume create nfsdata --driver local \
+    --opt type=nfs --opt o=addr=10.0.0.5,rw --opt device=:/exports/data`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runVolumeCreate,
+}
+
+var volumeInspectCmd = &cobra.Command{
+	Use:   "inspect <volume>...",
+	Short: "Show detailed information about volumes",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runVolumeInspect,
+}
+
+var volumeRmCmd = &cobra.Command{
+	Use:     "rm <volume>...",
+	Aliases: []string{"remove"},
+	Short:   "Remove volumes",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    runVolumeRm,
+}
+
+var volumePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove unused volumes",
+	Long: `Remove volumes not used by any container.
+
+By default Docker only prunes anonymous volumes; use --all to include named ones.`,
+	Args: cobra.NoArgs,
+	RunE: runVolumePrune,
+}
+
+func init() {
+	volumeLsCmd.Flags().StringArrayVar(&volumeFilters, "filter", []string{}, "Filter volumes (e.g. driver=local,label=app=db,dangling=true,name=data)")
+
+	volumeCreateCmd.Flags().StringVarP(&volumeDriver, "driver", "d", "local", "Volume driver")
+	volumeCreateCmd.Flags().StringArrayVar(&volumeOpts, "opt", []string{}, "Driver option (KEY=VALUE, can be specified multiple times)")
+	volumeCreateCmd.Flags().StringArrayVarP(&volumeLabels, "label", "l", []string{}, "Volume label (KEY=VALUE, can be specified multiple times)")
+
+	volumeRmCmd.Flags().BoolVarP(&volumeRmForce, "force", "f", false, "Force removal")
+
+	volumePruneCmd.Flags().BoolVarP(&volumePruneForce, "force", "f", false, "Do not prompt for confirmation")
+	volumePruneCmd.Flags().BoolVarP(&volumePruneAll, "all", "a", false, "Remove all unused volumes, not just anonymous ones")
+	volumePruneCmd.Flags().StringArrayVar(&volumePruneFilters, "filter", []string{}, "Only prune volumes matching a filter (e.g. label=tmp)")
+
+	volumeCmd.AddCommand(volumeLsCmd, volumeCreateCmd, volumeInspectCmd, volumeRmCmd, volumePruneCmd)
+	rootCmd.AddCommand(volumeCmd)
+}
+
+func runVolumeLs(cmd *cobra.Command, args []string) error {
+	filters, err := parseFilters(volumeFilters, nil)
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	volumes, err := client.ListVolumes(ctx, filters)
+	if err != nil {
+		return fmt.Errorf("failed to fetch volumes: %w", err)
+	}
+
+	return printList(volumes, func(v backend.Volume) string { return v.Name }, func() {
+		if len(volumes) == 0 {
+			fmt.Println("No volumes found.")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		if outputFlag == outputWide {
+			fmt.Fprintln(w, "NAME\tDRIVER\tSCOPE\tUSED BY\tMOUNTPOINT\tCREATED")
+		} else {
+			fmt.Fprintln(w, "NAME\tDRIVER\tUSED BY")
+		}
+
+		for _, v := range volumes {
+			usedBy := strings.Join(v.UsedBy, ", ")
+			if usedBy == "" {
+				usedBy = "-"
+			}
+
+			if outputFlag == outputWide {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", v.Name, v.Driver, v.Scope, usedBy, v.Mountpoint,
+					v.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", v.Name, v.Driver, usedBy)
+		}
+
+		w.Flush()
+	})
+}
+
+func runVolumeCreate(cmd *cobra.Command, args []string) error {
+	driverOpts, err := parseKeyValues(volumeOpts)
+	if err != nil {
+		return fmt.Errorf("invalid driver option: %w", err)
+	}
+
+	labels, err := parseKeyValues(volumeLabels)
+	if err != nil {
+		return fmt.Errorf("invalid label: %w", err)
+	}
+
+	opts := backend.VolumeCreateOptions{
+		Driver:     volumeDriver,
+		DriverOpts: driverOpts,
+		Labels:     labels,
+	}
+	if len(args) == 1 {
+		opts.Name = args[0]
+	}
+
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	volume, err := client.CreateVolume(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to create volume: %w", err)
+	}
+
+	return printResult(volume, volume.Name, func() {
+		fmt.Printf("✓ Volume %s created (driver: %s)\n", volume.Name, volume.Driver)
+	})
+}
+
+func runVolumeInspect(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	volumes := make([]backend.Volume, 0, len(args))
+	for _, name := range args {
+		volume, err := client.InspectVolume(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to inspect volume: %w", err)
+		}
+		volumes = append(volumes, *volume)
+	}
+
+	// Like docker volume inspect, the human-readable default is JSON
+	if !machineOutput() {
+		return writeJSON(os.Stdout, volumes)
+	}
+
+	return printList(volumes, func(v backend.Volume) string { return v.Name }, nil)
+}
+
+func runVolumeRm(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var results []resourceResult
+	for _, name := range args {
+		progressf("Removing volume %s...\n", name)
+
+		if err := client.RemoveVolume(ctx, name, volumeRmForce); err != nil {
+			return fmt.Errorf("failed to remove volume: %w", err)
+		}
+		results = append(results, resourceResult{Action: "remove", Kind: "volume", Name: name, Status: "removed"})
+	}
+
+	return printList(results, func(r resourceResult) string { return r.Name }, func() {
+		fmt.Println("✓ Volume(s) removed successfully")
+	})
+}
+
+func runVolumePrune(cmd *cobra.Command, args []string) error {
+	filters, err := parseFilters(volumePruneFilters, nil)
+	if err != nil {
+		return err
+	}
+	if volumePruneAll {
+		filters["all"] = []string{"true"}
+	}
+
+	if !volumePruneForce {
+		question := "This will remove all unused anonymous volumes. Continue?"
+		if volumePruneAll {
+			question = "This will remove all unused volumes, including named ones. Continue?"
+		}
+		confirmed, err := confirm(question)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return fmt.Errorf("prune cancelled")
+		}
+	}
+
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	report, err := client.PruneVolumes(ctx, filters)
+	if err != nil {
+		return fmt.Errorf("failed to prune volumes: %w", err)
+	}
+
+	return printResult(report, strings.Join(report.Deleted, "\n"), func() {
+		for _, name := range report.Deleted {
+			fmt.Printf("  Deleted: %s\n", name)
+		}
+		fmt.Printf("✓ Pruned %d volume(s), reclaimed %s\n", len(report.Deleted), formatBytes(report.SpaceReclaimed))
+	})
+}
+
+// parseKeyValues parses KEY=VALUE flags such as labels and driver options
+func parseKeyValues(values []string) (map[string]string, error) {
+	result := make(map[string]string)
+
+	for _, value := range values {
+		key, val, ok := strings.Cut(value, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("must be in format KEY=VALUE (got: %s)", value)
+		}
+		result[key] = val
+	}
+
+	return result, nil
+}
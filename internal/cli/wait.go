@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/Elias-Larsson/remdoc/internal/backend"
+	"github.com/spf13/cobra"
+)
+
+// Wait flags, shared by commands that start containers
+var (
+	waitReady   bool
+	waitTimeout time.Duration
+)
+
+const (
+	// waitInterval is how often container state is polled
+	waitInterval = time.Second
+
+	// waitStable is how long a container without a HEALTHCHECK must keep
+	// running before it counts as ready, to catch crash loops
+	waitStable = 5 * time.Second
+
+	// waitLogLines is how many log lines are shown when a container fails
+	waitLogLines = "20"
+)
+
+// addWaitFlags registers --wait and --wait-timeout on a command
+func addWaitFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&waitReady, "wait", false, "Wait until containers are running and healthy")
+	cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", 2*time.Minute, "Maximum time to wait with --wait")
+}
+
+// waitContext bounds a --wait by --wait-timeout and interrupts
+func waitContext() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	ctx, cancel := context.WithTimeout(ctx, waitTimeout)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
+
+// waitForContainer polls a container until it is healthy, or running for
+// waitStable if it has no HEALTHCHECK. It fails if the container exits,
+// restarts or turns unhealthy, printing its last log lines to stderr, or if
+// it is removed while waiting (e.g. an exited --rm container). A container
+// without a restart policy that exits with code 0 has completed
+// successfully. It returns the final status.
+func waitForContainer(ctx context.Context, client backend.Backend, containerID string) (string, error) {
+	progressf("Waiting for %s to become ready...\n", containerID)
+
+	// Track how long it has been running by the local clock, since the
+	// remote host's clock may differ
+	var startedAt, runningSince time.Time
+	restarts := -1
+	status := "unknown"
+
+	ticker := time.NewTicker(waitInterval)
+	defer ticker.Stop()
+
+	for {
+		details, err := client.InspectContainer(ctx, containerID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return status, waitTimedOut(containerID, status)
+			}
+			// Callers only wait for containers they just created or started,
+			// so a missing one is an auto-remove container that already exited,
+			// taking its exit code and logs with it
+			if errors.Is(err, backend.ErrNotFound) {
+				return "removed", fmt.Errorf("container %s exited and was removed before becoming ready", containerID)
+			}
+			return status, fmt.Errorf("failed to inspect container: %w", err)
+		}
+
+		state := details.State
+		status = state.Status
+		if state.Health != nil {
+			status = state.Health.Status
+		}
+
+		if restarts < 0 {
+			restarts = details.RestartCount
+		}
+
+		switch {
+		case details.RestartCount > restarts || state.Restarting:
+			return status, containerFailed(client, details, fmt.Sprintf("is restarting (last exit code %d)", state.ExitCode))
+		case !state.Running && state.Status == "exited" && state.ExitCode == 0 &&
+			(details.RestartPolicy == "" || details.RestartPolicy == "no"):
+			return "completed", nil
+		case !state.Running && state.Status != "created":
+			reason := fmt.Sprintf("exited with code %d", state.ExitCode)
+			if state.OOMKilled {
+				reason += " (out of memory)"
+			}
+			return status, containerFailed(client, details, reason)
+		case state.Health != nil && state.Health.Status == "unhealthy":
+			return status, containerFailed(client, details, "is unhealthy")
+		case state.Health != nil && state.Health.Status == "healthy":
+			return status, nil
+		case state.Health == nil && state.Running:
+			if runningSince.IsZero() || !state.StartedAt.Equal(startedAt) {
+				startedAt, runningSince = state.StartedAt, time.Now()
+			}
+			if time.Since(runningSince) >= waitStable {
+				return status, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, waitTimedOut(containerID, status)
+		case <-ticker.C:
+		}
+	}
+}
+
+func waitTimedOut(containerID, status string) error {
+	return fmt.Errorf("timed out after %s waiting for %s (status: %s)", waitTimeout, containerID, status)
+}
+
+// containerFailed prints a failed container's last health check output and
+// log lines to stderr and returns an error describing the failure
+func containerFailed(client backend.Backend, details *backend.ContainerDetails, reason string) error {
+	if h := details.State.Health; h != nil && len(h.Log) > 0 {
+		probe := h.Log[len(h.Log)-1]
+		if output := strings.TrimSpace(probe.Output); output != "" {
+			fmt.Fprintf(os.Stderr, "Last health check of %s (exit code %d):\n%s\n", details.Name, probe.ExitCode, output)
+		}
+	}
+
+	// The wait context may already be done, so fetch logs separately
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	var logs bytes.Buffer
+	err := client.ContainerLogs(ctx, details.ID, backend.LogsOptions{Tail: waitLogLines}, &logs, &logs)
+	if err == nil && logs.Len() > 0 {
+		fmt.Fprintf(os.Stderr, "Last log lines of %s:\n%s", details.Name, logs.String())
+		if !bytes.HasSuffix(logs.Bytes(), []byte("\n")) {
+			fmt.Fprintln(os.Stderr)
+		}
+	}
+
+	return fmt.Errorf("container %s %s", details.Name, reason)
+}
+
+// waitForStack waits for every container of a compose stack
+func waitForStack(ctx context.Context, client backend.Backend, stack string) error {
+	containers, err := client.ListContainers(ctx, backend.ListOptions{
+		All:     true,
+		Filters: map[string][]string{"label": {"com.docker.compose.project=" + stack}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch stack containers: %w", err)
+	}
+	if len(containers) == 0 {
+		return fmt.Errorf("no containers found for stack %s", stack)
+	}
+
+	for _, c := range containers {
+		status, err := waitForContainer(ctx, client, c.Name)
+		if err != nil {
+			return err
+		}
+		progressf("  %s is %s\n", c.Name, status)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+// Package compose parses Docker Compose files locally so they can be
+// interpolated, validated and previewed before they are sent to a backend.
+package compose
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Project is a parsed and interpolated compose file
+type Project struct {
+	root *yaml.Node
+
+	// Warnings lists non-fatal problems, such as variables that are not set
+	Warnings []string
+
+	// interpolated is set once ${VAR} references have been resolved
+	interpolated bool
+}
+
+// Parse parses a compose file without resolving ${VAR} references
+func Parse(content []byte) (*Project, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		return nil, fmt.Errorf("compose file is empty")
+	}
+	if root.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: compose file must be a mapping", root.Content[0].Line)
+	}
+
+	return &Project{root: &root}, nil
+}
+
+// Interpolate resolves ${VAR} references against env. Like docker compose,
+// unset variables become empty strings with a warning.
+func (p *Project) Interpolate(env map[string]string) error {
+	i := &interpolator{env: env, warned: make(map[string]bool)}
+	if err := i.node(p.root.Content[0]); err != nil {
+		return err
+	}
+	p.Warnings = append(p.Warnings, i.warnings...)
+	p.interpolated = true
+	return nil
+}
+
+// Clone returns a deep copy of the project, so it can be interpolated
+// without changing the original
+func (p *Project) Clone() *Project {
+	copies := make(map[*yaml.Node]*yaml.Node)
+
+	var clone func(n *yaml.Node) *yaml.Node
+	clone = func(n *yaml.Node) *yaml.Node {
+		// An anchor is copied once, whether it is reached through the tree
+		// or through an alias first, so aliases keep pointing at it
+		if c, ok := copies[n]; ok {
+			return c
+		}
+		c := *n
+		copies[n] = &c
+		if n.Alias != nil {
+			c.Alias = clone(n.Alias)
+		}
+		c.Content = make([]*yaml.Node, len(n.Content))
+		for i, child := range n.Content {
+			c.Content[i] = clone(child)
+		}
+		return &c
+	}
+
+	return &Project{
+		root:         clone(p.root),
+		Warnings:     append([]string(nil), p.Warnings...),
+		interpolated: p.interpolated,
+	}
+}
+
+// Bytes returns the effective compose file after interpolation
+func (p *Project) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(p.root); err != nil {
+		return nil, fmt.Errorf("failed to encode compose file: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode compose file: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Map returns the effective compose file as generic values, with anchors
+// and merge keys resolved
+func (p *Project) Map() (map[string]interface{}, error) {
+	var result map[string]interface{}
+	if err := p.root.Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode compose file: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,101 @@
+package compose
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "mapping", content: "services: {}\n"},
+		{name: "empty", content: "", wantErr: "compose file is empty"},
+		{name: "comment only", content: "# nothing\n", wantErr: "compose file is empty"},
+		{name: "list", content: "- web\n", wantErr: "line 1: compose file must be a mapping"},
+		{name: "invalid YAML", content: "services: [web\n", wantErr: "invalid YAML"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.content))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClone(t *testing.T) {
+	original := mustParse(t, `
+x-image: &image nginx:${TAG}
+services:
+  web:
+    image: *image
+  api:
+    image: *image
+`)
+
+	clone := original.Clone()
+	if err := clone.Interpolate(map[string]string{"TAG": "1.27"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := clone.Map()
+	if err != nil {
+		t.Fatal(err)
+	}
+	services := got["services"].(map[string]interface{})
+	for _, name := range []string{"web", "api"} {
+		if image := services[name].(map[string]interface{})["image"]; image != "nginx:1.27" {
+			t.Errorf("clone %s image = %v, want nginx:1.27", name, image)
+		}
+	}
+
+	// Aliases in the clone must point at the cloned anchor, not the original
+	want, err := original.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(want), "nginx:${TAG}") || strings.Contains(string(want), "1.27") {
+		t.Errorf("interpolating the clone changed the original:\n%s", want)
+	}
+}
+
+func TestCloneAliasBeforeAnchor(t *testing.T) {
+	// Parsed files always define an anchor before its aliases, but trees
+	// built in code need not
+	anchor := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "${TAG}", Anchor: "tag"}
+	alias := &yaml.Node{Kind: yaml.AliasNode, Value: "tag", Alias: anchor}
+	root := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "first"}, alias,
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "second"}, anchor,
+	}}
+	original := &Project{root: &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}}
+
+	clone := original.Clone()
+	cloned := clone.root.Content[0]
+	if cloned.Content[1].Alias != cloned.Content[3] {
+		t.Fatal("cloned alias does not point at the cloned anchor")
+	}
+
+	if err := clone.Interpolate(map[string]string{"TAG": "1.27"}); err != nil {
+		t.Fatal(err)
+	}
+	if anchor.Value != "${TAG}" {
+		t.Errorf("interpolating the clone changed the original anchor to %q", anchor.Value)
+	}
+	if cloned.Content[1].Alias.Value != "1.27" {
+		t.Errorf("cloned alias resolves to %q, want 1.27", cloned.Content[1].Alias.Value)
+	}
+}
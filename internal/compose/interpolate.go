@@ -0,0 +1,185 @@
+package compose
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// interpolator resolves ${VAR} references in scalar values
+type interpolator struct {
+	env      map[string]string
+	warnings []string
+	warned   map[string]bool
+}
+
+// node interpolates every value below n. Mapping keys are left alone, as in
+// docker compose.
+func (i *interpolator) node(n *yaml.Node) error {
+	switch n.Kind {
+	case yaml.MappingNode:
+		for k := 1; k < len(n.Content); k += 2 {
+			// yaml.v3 would otherwise re-encode merge keys as "!!merge <<"
+			if key := n.Content[k-1]; key.Tag == "!!merge" {
+				key.Tag = ""
+			}
+			if err := i.node(n.Content[k]); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for _, item := range n.Content {
+			if err := i.node(item); err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		value, err := i.expand(n.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", n.Line, err)
+		}
+		if value != n.Value {
+			n.Value = value
+			// Let an unquoted "${PORT}" become a number again
+			if n.Style == 0 {
+				n.Tag = ""
+			}
+		}
+	}
+
+	return nil
+}
+
+// expand resolves $VAR, ${VAR} and the ${VAR:-default}, ${VAR-default},
+// ${VAR:?error}, ${VAR?error}, ${VAR:+alt} and ${VAR+alt} forms. $$ is a
+// literal dollar sign.
+func (i *interpolator) expand(s string) (string, error) {
+	if !strings.Contains(s, "$") {
+		return s, nil
+	}
+
+	var out strings.Builder
+	for pos := 0; pos < len(s); pos++ {
+		if s[pos] != '$' || pos == len(s)-1 {
+			out.WriteByte(s[pos])
+			continue
+		}
+
+		next := s[pos+1]
+		switch {
+		case next == '$':
+			out.WriteByte('$')
+			pos++
+		case next == '{':
+			end := matchingBrace(s, pos+1)
+			if end < 0 {
+				return "", fmt.Errorf("unterminated variable reference in %q", s)
+			}
+			value, err := i.braced(s[pos+2 : end])
+			if err != nil {
+				return "", err
+			}
+			out.WriteString(value)
+			pos = end
+		case isNameStart(next):
+			end := pos + 1
+			for end < len(s) && isNameChar(s[end]) {
+				end++
+			}
+			out.WriteString(i.lookup(s[pos+1 : end]))
+			pos = end - 1
+		default:
+			out.WriteByte('$')
+		}
+	}
+
+	return out.String(), nil
+}
+
+// braced resolves the inside of a ${...} reference
+func (i *interpolator) braced(expr string) (string, error) {
+	end := 0
+	for end < len(expr) && isNameChar(expr[end]) {
+		end++
+	}
+	name, rest := expr[:end], expr[end:]
+	if name == "" || !isNameStart(name[0]) {
+		return "", fmt.Errorf("invalid variable reference ${%s}", expr)
+	}
+
+	value, set := i.env[name]
+
+	if rest == "" {
+		return i.lookup(name), nil
+	}
+
+	op := rest[:1]
+	if strings.HasPrefix(rest, ":") && len(rest) > 1 {
+		op = rest[:2]
+	}
+	arg := rest[len(op):]
+
+	switch op {
+	case ":-", "-":
+		if set && (op == "-" || value != "") {
+			return value, nil
+		}
+		return i.expand(arg)
+	case ":?", "?":
+		if set && (op == "?" || value != "") {
+			return value, nil
+		}
+		message, err := i.expand(arg)
+		if err != nil {
+			return "", err
+		}
+		if message == "" {
+			return "", fmt.Errorf("required variable %s is not set", name)
+		}
+		return "", fmt.Errorf("required variable %s is missing a value: %s", name, message)
+	case ":+", "+":
+		if set && (op == "+" || value != "") {
+			return i.expand(arg)
+		}
+		return "", nil
+	default:
+		return "", fmt.Errorf("invalid variable reference ${%s}", expr)
+	}
+}
+
+// lookup returns a variable's value, warning once if it isn't set
+func (i *interpolator) lookup(name string) string {
+	value, ok := i.env[name]
+	if !ok && !i.warned[name] {
+		i.warned[name] = true
+		i.warnings = append(i.warnings, fmt.Sprintf("variable %s is not set, defaulting to a blank string", name))
+	}
+	return value
+}
+
+// matchingBrace returns the index of the } closing the { at open, allowing
+// nested ${...} in default values
+func matchingBrace(s string, open int) int {
+	depth := 0
+	for pos := open; pos < len(s); pos++ {
+		switch s[pos] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return pos
+			}
+		}
+	}
+	return -1
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
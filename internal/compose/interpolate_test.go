@@ -0,0 +1,128 @@
+package compose
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	env := map[string]string{
+		"TAG":   "1.4",
+		"EMPTY": "",
+		"PORT":  "8080",
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		want     string
+		wantErr  string
+		warnings int
+	}{
+		{name: "no variables", input: "nginx:latest", want: "nginx:latest"},
+		{name: "bare", input: "nginx:$TAG", want: "nginx:1.4"},
+		{name: "braced", input: "nginx:${TAG}-alpine", want: "nginx:1.4-alpine"},
+		{name: "bare name ends at non-name character", input: "$TAG.0", want: "1.4.0"},
+		{name: "unset warns", input: "${MISSING}", want: "", warnings: 1},
+
+		{name: "default when unset", input: "${MISSING:-latest}", want: "latest"},
+		{name: "default when empty", input: "${EMPTY:-latest}", want: "latest"},
+		{name: "dash default keeps empty", input: "${EMPTY-latest}", want: ""},
+		{name: "dash default when unset", input: "${MISSING-latest}", want: "latest"},
+		{name: "default not used when set", input: "${TAG:-latest}", want: "1.4"},
+		{name: "nested default", input: "${MISSING:-${TAG:-latest}}", want: "1.4"},
+		{name: "doubly nested default", input: "${MISSING:-${ALSO_MISSING:-${PORT}}}", want: "8080"},
+		{name: "default with colon", input: "${MISSING:-127.0.0.1:80}", want: "127.0.0.1:80"},
+
+		{name: "required set", input: "${TAG:?tag required}", want: "1.4"},
+		{name: "required unset", input: "${MISSING:?tag required}", wantErr: "required variable MISSING is missing a value: tag required"},
+		{name: "required empty", input: "${EMPTY:?}", wantErr: "required variable EMPTY is not set"},
+		{name: "question keeps empty", input: "${EMPTY?}", want: ""},
+		{name: "required message expands", input: "${MISSING:?set ${TAG}}", wantErr: "missing a value: set 1.4"},
+		{name: "required inside default", input: "${MISSING:-${ALSO_MISSING:?}}", wantErr: "required variable ALSO_MISSING"},
+
+		{name: "alternative when set", input: "${TAG:+--tag=$TAG}", want: "--tag=1.4"},
+		{name: "alternative when empty", input: "${EMPTY:+x}", want: ""},
+		{name: "plus alternative when empty", input: "${EMPTY+x}", want: "x"},
+		{name: "alternative when unset", input: "${MISSING+x}", want: ""},
+
+		{name: "escaped dollar", input: "$$HOME", want: "$HOME"},
+		{name: "escaped braces", input: "$${TAG}", want: "${TAG}"},
+		{name: "escaped then variable", input: "$$$TAG", want: "$1.4"},
+		{name: "trailing dollar", input: "cost$", want: "cost$"},
+		{name: "dollar before non-name", input: "$1 and $-", want: "$1 and $-"},
+
+		{name: "unterminated", input: "${TAG", wantErr: "unterminated variable reference"},
+		{name: "unterminated nested", input: "${MISSING:-${TAG}", wantErr: "unterminated variable reference"},
+		{name: "invalid name", input: "${1TAG}", wantErr: "invalid variable reference"},
+		{name: "empty name", input: "${}", wantErr: "invalid variable reference"},
+		{name: "unknown operator", input: "${TAG/x}", wantErr: "invalid variable reference"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := &interpolator{env: env, warned: make(map[string]bool)}
+			got, err := i.expand(tt.input)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expand(%q) error = %v, want %q", tt.input, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expand(%q) unexpected error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("expand(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if len(i.warnings) != tt.warnings {
+				t.Errorf("expand(%q) warnings = %q, want %d", tt.input, i.warnings, tt.warnings)
+			}
+		})
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	project, err := Parse([]byte(`
+x-base: &base
+  image: app:${TAG}
+services:
+  ${TAG}:
+    <<: *base
+    ports:
+      - ${PORT}
+      - "${PORT}"
+    environment:
+      A: ${MISSING}
+      B: ${MISSING}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := project.Interpolate(map[string]string{"TAG": "1.4", "PORT": "8080"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := project.Map()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Keys are not interpolated, unquoted numbers become numbers again and
+	// the anchor is resolved once for every alias
+	service := got["services"].(map[string]interface{})["${TAG}"].(map[string]interface{})
+	if service["image"] != "app:1.4" {
+		t.Errorf("image = %v, want app:1.4", service["image"])
+	}
+	if ports := service["ports"]; !reflect.DeepEqual(ports, []interface{}{8080, "8080"}) {
+		t.Errorf("ports = %#v, want [8080 \"8080\"]", ports)
+	}
+
+	// An unset variable is reported once however often it is used
+	if want := []string{"variable MISSING is not set, defaulting to a blank string"}; !reflect.DeepEqual(project.Warnings, want) {
+		t.Errorf("warnings = %q, want %q", project.Warnings, want)
+	}
+}
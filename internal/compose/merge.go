@@ -0,0 +1,252 @@
+package compose
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Lists that override files replace rather than extend
+var replacedLists = keySet("services.*.command", "services.*.entrypoint", "services.*.healthcheck.test")
+
+// Lists that may also be written as mappings and are merged key by key
+var mappingLists = keySet(
+	"services.*.environment", "services.*.labels", "services.*.annotations", "services.*.extra_hosts",
+	"services.*.sysctls", "services.*.networks", "services.*.depends_on",
+	"services.*.build.args", "services.*.build.labels", "services.*.deploy.labels",
+)
+
+// Lists whose entries are identified by a key, so overrides replace them
+var keyedLists = map[string]func(*yaml.Node) string{
+	"services.*.volumes": volumeTarget,
+	"services.*.secrets": fileSource,
+	"services.*.configs": fileSource,
+}
+
+// Merge combines compose files with Docker Compose's override rules. Later
+// files win: mappings are merged key by key, most lists are appended, and the
+// !reset and !override tags remove or replace a value. Anchors and merge keys
+// are resolved first because they cannot span files.
+func Merge(projects ...*Project) *Project {
+	root := flatten(projects[0].root.Content[0])
+	for _, p := range projects[1:] {
+		root = mergeNode(root, flatten(p.root.Content[0]), "")
+	}
+	clearTags(root)
+
+	merged := &Project{root: &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}}
+	for _, p := range projects {
+		merged.Warnings = append(merged.Warnings, p.Warnings...)
+	}
+	return merged
+}
+
+// flatten returns a copy of n with aliases and merge keys resolved
+func flatten(n *yaml.Node) *yaml.Node {
+	n = resolve(n)
+
+	c := *n
+	c.Anchor = ""
+	c.Content = nil
+
+	switch n.Kind {
+	case yaml.MappingNode:
+		for _, pair := range mappingPairs(n) {
+			c.Content = append(c.Content, flatten(pair[0]), flatten(pair[1]))
+		}
+	case yaml.SequenceNode:
+		for _, item := range n.Content {
+			c.Content = append(c.Content, flatten(item))
+		}
+	}
+
+	return &c
+}
+
+func mergeNode(base, override *yaml.Node, path string) *yaml.Node {
+	if override.Tag == "!override" {
+		return override
+	}
+
+	if mappingLists[path] {
+		base, override = listToMapping(base, path), listToMapping(override, path)
+	}
+
+	switch {
+	case base.Kind == yaml.MappingNode && override.Kind == yaml.MappingNode:
+		return mergeMapping(base, override, path)
+	case base.Kind == yaml.SequenceNode && override.Kind == yaml.SequenceNode:
+		return mergeSequence(base, override, path)
+	}
+
+	return override
+}
+
+func mergeMapping(base, override *yaml.Node, path string) *yaml.Node {
+	for k := 0; k+1 < len(override.Content); k += 2 {
+		key, value := override.Content[k], override.Content[k+1]
+		i := keyIndex(base, key.Value)
+
+		switch {
+		case i < 0 && value.Tag == "!reset":
+		case i < 0:
+			base.Content = append(base.Content, key, value)
+		case value.Tag == "!reset":
+			base.Content = append(base.Content[:i], base.Content[i+2:]...)
+		default:
+			base.Content[i+1] = mergeNode(base.Content[i+1], value, childPath(path, key.Value))
+		}
+	}
+	return base
+}
+
+func mergeSequence(base, override *yaml.Node, path string) *yaml.Node {
+	if replacedLists[path] {
+		return override
+	}
+
+	if keyOf, ok := keyedLists[path]; ok {
+		for _, item := range override.Content {
+			replaced := false
+			for i, existing := range base.Content {
+				if keyOf(existing) == keyOf(item) {
+					base.Content[i] = item
+					replaced = true
+					break
+				}
+			}
+			if !replaced {
+				base.Content = append(base.Content, item)
+			}
+		}
+		return base
+	}
+
+	// Append, dropping exact duplicates such as the same port twice
+	for _, item := range override.Content {
+		duplicate := false
+		for _, existing := range base.Content {
+			if item.Kind == yaml.ScalarNode && existing.Kind == yaml.ScalarNode && item.Value == existing.Value {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			base.Content = append(base.Content, item)
+		}
+	}
+	return base
+}
+
+// listToMapping converts the list form of environment, labels, networks and
+// similar keys to their mapping form
+func listToMapping(n *yaml.Node, path string) *yaml.Node {
+	if n.Kind != yaml.SequenceNode {
+		return n
+	}
+
+	m := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: n.Line, Column: n.Column}
+	for _, item := range n.Content {
+		key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: item.Value, Line: item.Line, Column: item.Column}
+		value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Line: item.Line, Column: item.Column}
+
+		switch {
+		case strings.HasSuffix(path, ".depends_on"):
+			value = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: "condition"},
+				{Kind: yaml.ScalarNode, Tag: "!!str", Value: "service_started"},
+			}}
+		case strings.HasSuffix(path, ".networks"):
+		default:
+			sep := "="
+			if strings.HasSuffix(path, ".extra_hosts") && !strings.Contains(item.Value, "=") {
+				sep = ":"
+			}
+			if name, val, ok := strings.Cut(item.Value, sep); ok {
+				key.Value = name
+				value = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: val, Line: item.Line, Column: item.Column}
+			}
+		}
+
+		m.Content = append(m.Content, key, value)
+	}
+	return m
+}
+
+// clearTags drops values still tagged !reset and the !override tags
+// themselves, which only have meaning while merging
+func clearTags(n *yaml.Node) {
+	switch n.Kind {
+	case yaml.MappingNode:
+		content := n.Content[:0]
+		for k := 0; k+1 < len(n.Content); k += 2 {
+			if n.Content[k+1].Tag != "!reset" {
+				content = append(content, n.Content[k], n.Content[k+1])
+			}
+		}
+		n.Content = content
+	case yaml.SequenceNode:
+		content := n.Content[:0]
+		for _, item := range n.Content {
+			if item.Tag != "!reset" {
+				content = append(content, item)
+			}
+		}
+		n.Content = content
+	}
+
+	if n.Tag == "!override" {
+		n.Tag = ""
+		n.Style &^= yaml.TaggedStyle
+	}
+	for _, child := range n.Content {
+		clearTags(child)
+	}
+}
+
+// childPath extends a merge path, using * for service names
+func childPath(path, key string) string {
+	switch path {
+	case "":
+		return key
+	case "services":
+		return "services.*"
+	}
+	return path + "." + key
+}
+
+func keyIndex(n *yaml.Node, key string) int {
+	for k := 0; k+1 < len(n.Content); k += 2 {
+		if n.Content[k].Value == key {
+			return k
+		}
+	}
+	return -1
+}
+
+// volumeTarget identifies a service volume by its container path
+func volumeTarget(n *yaml.Node) string {
+	if n.Kind == yaml.MappingNode {
+		if i := keyIndex(n, "target"); i >= 0 {
+			return n.Content[i+1].Value
+		}
+		return ""
+	}
+
+	parts := strings.Split(n.Value, ":")
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	return parts[1]
+}
+
+// fileSource identifies a service secret or config by its source
+func fileSource(n *yaml.Node) string {
+	if n.Kind == yaml.MappingNode {
+		if i := keyIndex(n, "source"); i >= 0 {
+			return n.Content[i+1].Value
+		}
+		return ""
+	}
+	return n.Value
+}
@@ -0,0 +1,328 @@
+package compose
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     string
+		override string
+		want     string
+	}{
+		{
+			name: "mappings merge key by key",
+			base: `
+services:
+  web:
+    image: nginx:1.25
+    restart: always
+`,
+			override: `
+services:
+  web:
+    image: nginx:1.27
+  worker:
+    image: worker
+`,
+			want: `
+services:
+  web:
+    image: nginx:1.27
+    restart: always
+  worker:
+    image: worker
+`,
+		},
+		{
+			name: "lists append without duplicates",
+			base: `
+services:
+  web:
+    ports: ["80:80", "443:443"]
+`,
+			override: `
+services:
+  web:
+    ports: ["443:443", "8080:8080"]
+`,
+			want: `
+services:
+  web:
+    ports: ["80:80", "443:443", "8080:8080"]
+`,
+		},
+		{
+			name: "command and healthcheck test are replaced",
+			base: `
+services:
+  web:
+    command: [nginx, -g, daemon off;]
+    healthcheck:
+      test: [CMD, curl, -f, http://localhost]
+`,
+			override: `
+services:
+  web:
+    command: [nginx-debug]
+    healthcheck:
+      test: [CMD-SHELL, exit 0]
+`,
+			want: `
+services:
+  web:
+    command: [nginx-debug]
+    healthcheck:
+      test: [CMD-SHELL, exit 0]
+`,
+		},
+		{
+			name: "environment list and mapping forms merge by name",
+			base: `
+services:
+  web:
+    environment:
+      - LEVEL=info
+      - REGION=eu
+`,
+			override: `
+services:
+  web:
+    environment:
+      LEVEL: debug
+      EXTRA: "1"
+`,
+			want: `
+services:
+  web:
+    environment:
+      LEVEL: debug
+      REGION: eu
+      EXTRA: "1"
+`,
+		},
+		{
+			name: "depends_on list becomes conditions",
+			base: `
+services:
+  web:
+    depends_on: [db]
+`,
+			override: `
+services:
+  web:
+    depends_on:
+      cache:
+        condition: service_healthy
+`,
+			want: `
+services:
+  web:
+    depends_on:
+      db:
+        condition: service_started
+      cache:
+        condition: service_healthy
+`,
+		},
+		{
+			name: "volumes are keyed by container path",
+			base: `
+services:
+  web:
+    volumes:
+      - data:/var/lib/data
+      - ./conf:/etc/app:ro
+`,
+			override: `
+services:
+  web:
+    volumes:
+      - type: bind
+        source: ./data
+        target: /var/lib/data
+      - logs:/var/log
+`,
+			want: `
+services:
+  web:
+    volumes:
+      - type: bind
+        source: ./data
+        target: /var/lib/data
+      - ./conf:/etc/app:ro
+      - logs:/var/log
+`,
+		},
+		{
+			name: "secrets are keyed by source",
+			base: `
+services:
+  web:
+    secrets:
+      - db_password
+      - api_key
+`,
+			override: `
+services:
+  web:
+    secrets:
+      - source: db_password
+        target: password
+`,
+			want: `
+services:
+  web:
+    secrets:
+      - source: db_password
+        target: password
+      - api_key
+`,
+		},
+		{
+			name: "reset removes a value",
+			base: `
+services:
+  web:
+    image: nginx
+    ports: ["80:80"]
+    build: .
+`,
+			override: `
+services:
+  web:
+    ports: !reset []
+    build: !reset null
+`,
+			want: `
+services:
+  web:
+    image: nginx
+`,
+		},
+		{
+			name: "reset of a missing key is dropped",
+			base: `
+services:
+  web:
+    image: nginx
+`,
+			override: `
+services:
+  web:
+    ports: !reset []
+`,
+			want: `
+services:
+  web:
+    image: nginx
+`,
+		},
+		{
+			name: "override replaces instead of merging",
+			base: `
+services:
+  web:
+    ports: ["80:80"]
+    environment:
+      A: "1"
+`,
+			override: `
+services:
+  web:
+    ports: !override ["8080:80"]
+    environment: !override
+      B: "2"
+`,
+			want: `
+services:
+  web:
+    ports: ["8080:80"]
+    environment:
+      B: "2"
+`,
+		},
+		{
+			name: "anchors and merge keys are resolved before merging",
+			base: `
+x-common: &common
+  restart: always
+  environment:
+    LEVEL: info
+services:
+  web:
+    <<: *common
+    image: nginx
+`,
+			override: `
+services:
+  web:
+    environment:
+      LEVEL: debug
+`,
+			want: `
+x-common:
+  restart: always
+  environment:
+    LEVEL: info
+services:
+  web:
+    image: nginx
+    restart: always
+    environment:
+      LEVEL: debug
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := Merge(mustParse(t, tt.base), mustParse(t, tt.override))
+
+			got, err := merged.Map()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var want map[string]interface{}
+			if err := yaml.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				gotYAML, _ := merged.Bytes()
+				t.Errorf("merged file:\n%s\nwant:%s", gotYAML, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeKeepsOrder(t *testing.T) {
+	merged := Merge(
+		mustParse(t, "services:\n  b:\n    image: b\n  a:\n    image: a\n"),
+		mustParse(t, "services:\n  c:\n    image: c\n  a:\n    image: a2\n"),
+	)
+
+	got, err := merged.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "services:\n  b:\n    image: b\n  a:\n    image: a2\n  c:\n    image: c\n"
+	if string(got) != want {
+		t.Errorf("merged file:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func mustParse(t *testing.T, content string) *Project {
+	t.Helper()
+	project, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return project
+}
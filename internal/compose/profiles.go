@@ -0,0 +1,134 @@
+package compose
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Profiles returns the names of all profiles used by services, sorted
+func (p *Project) Profiles() []string {
+	seen := make(map[string]bool)
+	for _, service := range p.services() {
+		for _, profile := range serviceProfiles(service[1]) {
+			seen[profile] = true
+		}
+	}
+
+	profiles := make([]string, 0, len(seen))
+	for profile := range seen {
+		profiles = append(profiles, profile)
+	}
+	sort.Strings(profiles)
+	return profiles
+}
+
+// ApplyProfiles removes services that are not in an active profile ("*"
+// activates all of them) and drops the profiles key from the rest, so the
+// backend starts them without needing --profile itself. Services without
+// profiles are always enabled. It expects a project returned by Merge.
+func (p *Project) ApplyProfiles(active []string) error {
+	services := p.serviceNode()
+	if services == nil {
+		return nil
+	}
+
+	enabled := make(map[string]bool)
+	for _, service := range p.services() {
+		enabled[service[0].Value] = profileActive(serviceProfiles(service[1]), active)
+	}
+
+	content := services.Content[:0]
+	for k := 0; k+1 < len(services.Content); k += 2 {
+		name, service := services.Content[k], services.Content[k+1]
+		if !enabled[name.Value] {
+			continue
+		}
+
+		for _, dep := range dependencyNames(service) {
+			if isEnabled, defined := enabled[dep]; defined && !isEnabled {
+				return fmt.Errorf("service %s depends on %s, which is not in an active profile", name.Value, dep)
+			}
+		}
+
+		if i := keyIndex(service, "profiles"); i >= 0 {
+			service.Content = append(service.Content[:i], service.Content[i+2:]...)
+		}
+		content = append(content, name, service)
+	}
+	services.Content = content
+
+	return nil
+}
+
+func profileActive(profiles, active []string) bool {
+	if len(profiles) == 0 {
+		return true
+	}
+	for _, want := range active {
+		for _, profile := range profiles {
+			if want == "*" || want == profile {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// serviceNode returns the services mapping, if there is one
+func (p *Project) serviceNode() *yaml.Node {
+	root := p.root.Content[0]
+	if i := keyIndex(root, "services"); i >= 0 && root.Content[i+1].Kind == yaml.MappingNode {
+		return root.Content[i+1]
+	}
+	return nil
+}
+
+func (p *Project) services() [][2]*yaml.Node {
+	if services := p.serviceNode(); services != nil {
+		return mappingPairs(services)
+	}
+	return nil
+}
+
+func serviceProfiles(service *yaml.Node) []string {
+	var profiles []string
+	for _, pair := range mappingPairs(service) {
+		if pair[0].Value == "profiles" {
+			for _, item := range sequenceItems(resolve(pair[1])) {
+				profiles = append(profiles, item.Value)
+			}
+		}
+	}
+	return profiles
+}
+
+// dependencyNames returns the services listed in depends_on, which may be
+// a list or a mapping
+func dependencyNames(service *yaml.Node) []string {
+	var names []string
+	for _, pair := range mappingPairs(service) {
+		if pair[0].Value != "depends_on" {
+			continue
+		}
+		for _, item := range sequenceItems(resolve(pair[1])) {
+			names = append(names, item.Value)
+		}
+		for _, dep := range mappingPairs(pair[1]) {
+			names = append(names, dep[0].Value)
+		}
+	}
+	return names
+}
+
+func sequenceItems(n *yaml.Node) []*yaml.Node {
+	if n.Kind != yaml.SequenceNode {
+		return nil
+	}
+	items := make([]*yaml.Node, len(n.Content))
+	for i, item := range n.Content {
+		items[i] = resolve(item)
+	}
+	return items
+}
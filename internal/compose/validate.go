@@ -0,0 +1,482 @@
+package compose
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationError lists every problem found in a compose file, ordered by line
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%d problem(s) found:\n  - %s", len(e.Problems), strings.Join(e.Problems, "\n  - "))
+}
+
+var topLevelKeys = keySet("version", "name", "include", "services", "networks", "volumes", "configs", "secrets", "models")
+
+var serviceKeys = keySet(
+	"annotations", "attach", "blkio_config", "build", "cap_add", "cap_drop", "cgroup", "cgroup_parent",
+	"command", "configs", "container_name", "cpu_count", "cpu_percent", "cpu_period", "cpu_quota",
+	"cpu_rt_period", "cpu_rt_runtime", "cpu_shares", "cpus", "cpuset", "credential_spec", "depends_on",
+	"deploy", "develop", "device_cgroup_rules", "devices", "dns", "dns_opt", "dns_search", "domainname",
+	"entrypoint", "env_file", "environment", "expose", "extends", "external_links", "extra_hosts", "gpus",
+	"group_add", "healthcheck", "hostname", "image", "init", "ipc", "isolation", "label_file", "labels",
+	"links", "logging", "mac_address", "mem_limit", "mem_reservation", "mem_swappiness", "memswap_limit",
+	"models", "network_mode", "networks", "oom_kill_disable", "oom_score_adj", "pid", "pids_limit",
+	"platform", "ports", "post_start", "pre_stop", "privileged", "profiles", "provider", "pull_policy",
+	"read_only", "restart", "runtime", "scale", "secrets", "security_opt", "shm_size", "stdin_open",
+	"stop_grace_period", "stop_signal", "storage_opt", "sysctls", "tmpfs", "tty", "ulimits",
+	"use_api_socket", "user", "userns_mode", "uts", "volumes", "volumes_from", "working_dir",
+)
+
+var portKeys = keySet("target", "published", "host_ip", "protocol", "mode", "name", "app_protocol")
+
+var volumeMountKeys = keySet("type", "source", "target", "read_only", "bind", "volume", "tmpfs", "image", "consistency")
+
+var volumeTypes = keySet("volume", "bind", "tmpfs", "npipe", "cluster", "image")
+
+func keySet(keys ...string) map[string]bool {
+	set := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		set[key] = true
+	}
+	return set
+}
+
+// problem is a validation failure and the line it was found on
+type problem struct {
+	line    int
+	message string
+}
+
+// validator collects problems while walking a compose file
+type validator struct {
+	problems []problem
+	raw      bool // values may still contain ${VAR} references
+	networks map[string]bool
+	volumes  map[string]bool
+	services map[string]bool
+}
+
+// unresolved reports whether a value can't be checked yet because it still
+// contains variable references
+func (v *validator) unresolved(n *yaml.Node) bool {
+	return v.raw && strings.Contains(n.Value, "$")
+}
+
+func (v *validator) addf(n *yaml.Node, field, format string, args ...interface{}) {
+	v.problems = append(v.problems, problem{
+		line:    n.Line,
+		message: fmt.Sprintf("line %d: %s: %s", n.Line, field, fmt.Sprintf(format, args...)),
+	})
+}
+
+// Validate checks the structure of services, ports, volumes and networks and
+// returns a *ValidationError listing every problem found. Before Interpolate,
+// values that contain variable references are not checked, since they are
+// only known once the variables are resolved.
+func (p *Project) Validate() error {
+	v := &validator{
+		raw:      !p.interpolated,
+		networks: map[string]bool{"default": true},
+		volumes:  make(map[string]bool),
+		services: make(map[string]bool),
+	}
+
+	root := p.root.Content[0]
+	var services *yaml.Node
+
+	for _, pair := range mappingPairs(root) {
+		key, value := pair[0].Value, pair[1]
+		switch {
+		case strings.HasPrefix(key, "x-"):
+		case !topLevelKeys[key]:
+			v.addf(pair[0], key, "unknown top-level key")
+		case key == "services":
+			services = value
+		case key == "networks":
+			v.declarations(value, key, v.networks)
+		case key == "volumes":
+			v.declarations(value, key, v.volumes)
+		}
+	}
+
+	if services == nil {
+		v.problems = append(v.problems, problem{message: "services: no services defined"})
+	} else if resolve(services).Kind != yaml.MappingNode {
+		v.addf(services, "services", "must be a mapping of service names")
+	} else {
+		pairs := mappingPairs(services)
+		for _, pair := range pairs {
+			v.services[pair[0].Value] = true
+		}
+		for _, pair := range pairs {
+			v.service(pair[0].Value, pair[1])
+		}
+		if len(pairs) == 0 {
+			v.addf(services, "services", "no services defined")
+		}
+	}
+
+	if len(v.problems) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(v.problems, func(i, j int) bool { return v.problems[i].line < v.problems[j].line })
+
+	messages := make([]string, len(v.problems))
+	for i, p := range v.problems {
+		messages[i] = p.message
+	}
+	return &ValidationError{Problems: messages}
+}
+
+// declarations records top-level networks or volumes
+func (v *validator) declarations(n *yaml.Node, field string, names map[string]bool) {
+	n = resolve(n)
+	if isNull(n) {
+		return
+	}
+	if n.Kind != yaml.MappingNode {
+		v.addf(n, field, "must be a mapping")
+		return
+	}
+
+	for _, pair := range mappingPairs(n) {
+		names[pair[0].Value] = true
+		if value := resolve(pair[1]); !isNull(value) && value.Kind != yaml.MappingNode {
+			v.addf(value, field+"."+pair[0].Value, "must be a mapping or empty")
+		}
+	}
+}
+
+func (v *validator) service(name string, n *yaml.Node) {
+	field := "services." + name
+	n = resolve(n)
+	if n.Kind != yaml.MappingNode {
+		v.addf(n, field, "must be a mapping")
+		return
+	}
+
+	hasImage, hasNetworks, hasNetworkMode := false, false, false
+
+	for _, pair := range mappingPairs(n) {
+		key, value := pair[0].Value, resolve(pair[1])
+		keyField := field + "." + key
+
+		if strings.HasPrefix(key, "x-") {
+			continue
+		}
+		if !serviceKeys[key] {
+			v.addf(pair[0], field, "unknown key %q", key)
+			continue
+		}
+
+		switch key {
+		case "image", "build", "extends":
+			hasImage = true
+		case "ports":
+			v.ports(value, keyField)
+		case "volumes":
+			v.serviceVolumes(value, keyField)
+		case "networks":
+			hasNetworks = true
+			v.serviceNetworks(value, keyField)
+		case "network_mode":
+			hasNetworkMode = true
+		case "depends_on":
+			v.dependsOn(value, keyField, name)
+		case "restart":
+			if v.unresolved(value) {
+				continue
+			}
+			policy, _, _ := strings.Cut(value.Value, ":")
+			switch policy {
+			case "no", "always", "on-failure", "unless-stopped":
+			default:
+				v.addf(value, keyField, "unsupported restart policy %q (use no, always, on-failure or unless-stopped)", value.Value)
+			}
+		}
+	}
+
+	if !hasImage {
+		v.addf(n, field, "must specify an image or build")
+	}
+	if hasNetworks && hasNetworkMode {
+		v.addf(n, field, "networks and network_mode cannot be used together")
+	}
+}
+
+func (v *validator) ports(n *yaml.Node, field string) {
+	if n.Kind != yaml.SequenceNode {
+		v.addf(n, field, "must be a list")
+		return
+	}
+
+	for i, item := range n.Content {
+		item = resolve(item)
+		itemField := fmt.Sprintf("%s[%d]", field, i)
+
+		if item.Kind == yaml.MappingNode {
+			hasTarget := false
+			for _, pair := range mappingPairs(item) {
+				switch {
+				case pair[0].Value == "target":
+					hasTarget = true
+					if v.unresolved(pair[1]) {
+						continue
+					}
+					if err := checkPortRange(pair[1].Value); err != nil {
+						v.addf(pair[1], itemField+".target", "%v", err)
+					}
+				case !portKeys[pair[0].Value]:
+					v.addf(pair[0], itemField, "unknown key %q", pair[0].Value)
+				}
+			}
+			if !hasTarget {
+				v.addf(item, itemField, "target is required")
+			}
+			continue
+		}
+
+		if v.unresolved(item) {
+			continue
+		}
+		if err := checkPortSpec(item.Value); err != nil {
+			v.addf(item, itemField, "%v", err)
+		}
+	}
+}
+
+// checkPortSpec validates short port syntax: [[HOST_IP:]HOST_PORT:]CONTAINER_PORT[/PROTOCOL]
+func checkPortSpec(spec string) error {
+	if rest, protocol, ok := strings.Cut(spec, "/"); ok {
+		if protocol != "tcp" && protocol != "udp" && protocol != "sctp" {
+			return fmt.Errorf("unsupported protocol %q in %q", protocol, spec)
+		}
+		spec = rest
+	}
+
+	// Drop a host IP, which may be a bracketed IPv6 address
+	if strings.HasPrefix(spec, "[") {
+		end := strings.Index(spec, "]:")
+		if end < 0 {
+			return fmt.Errorf("invalid host address in %q", spec)
+		}
+		spec = spec[end+2:]
+	}
+
+	parts := strings.Split(spec, ":")
+	if len(parts) > 3 {
+		return fmt.Errorf("invalid port mapping %q", spec)
+	}
+	if len(parts) == 3 {
+		parts = parts[1:]
+	}
+
+	if err := checkPortRange(parts[len(parts)-1]); err != nil {
+		return fmt.Errorf("invalid container port in %q: %w", spec, err)
+	}
+	if len(parts) == 2 && parts[0] != "" {
+		if err := checkPortRange(parts[0]); err != nil {
+			return fmt.Errorf("invalid host port in %q: %w", spec, err)
+		}
+	}
+
+	return nil
+}
+
+// checkPortRange validates a port or START-END range
+func checkPortRange(value string) error {
+	start, end, isRange := strings.Cut(value, "-")
+	ports := []string{start}
+	if isRange {
+		ports = append(ports, end)
+	}
+	for _, port := range ports {
+		n, err := strconv.Atoi(port)
+		if err != nil || n < 1 || n > 65535 {
+			return fmt.Errorf("%q is not a valid port (1-65535)", value)
+		}
+	}
+	return nil
+}
+
+func (v *validator) serviceVolumes(n *yaml.Node, field string) {
+	if n.Kind != yaml.SequenceNode {
+		v.addf(n, field, "must be a list")
+		return
+	}
+
+	for i, item := range n.Content {
+		item = resolve(item)
+		itemField := fmt.Sprintf("%s[%d]", field, i)
+
+		if item.Kind == yaml.MappingNode {
+			var volumeType, source, target *yaml.Node
+			for _, pair := range mappingPairs(item) {
+				switch pair[0].Value {
+				case "type":
+					volumeType = pair[1]
+				case "source":
+					source = pair[1]
+				case "target":
+					target = pair[1]
+				default:
+					if !volumeMountKeys[pair[0].Value] {
+						v.addf(pair[0], itemField, "unknown key %q", pair[0].Value)
+					}
+				}
+			}
+
+			if volumeType == nil || !v.unresolved(volumeType) && !volumeTypes[volumeType.Value] {
+				v.addf(item, itemField, "type must be one of volume, bind, tmpfs, npipe, cluster or image")
+			}
+			if target == nil || target.Value == "" {
+				v.addf(item, itemField, "target is required")
+			}
+			if volumeType != nil && volumeType.Value == "volume" && source != nil && source.Value != "" &&
+				!v.unresolved(source) && !v.volumes[source.Value] {
+				v.addf(item, itemField, "volume %q is not declared in the top-level volumes", source.Value)
+			}
+			continue
+		}
+
+		if v.unresolved(item) {
+			continue
+		}
+
+		parts := strings.Split(item.Value, ":")
+		if len(parts) > 3 {
+			v.addf(item, itemField, "must be in format [SOURCE:]TARGET[:MODE]")
+			continue
+		}
+
+		target := parts[0]
+		if len(parts) > 1 {
+			target = parts[1]
+		}
+		if !path.IsAbs(target) {
+			v.addf(item, itemField, "container path %q must be absolute", target)
+		}
+
+		// Sources that aren't paths are named volumes
+		if len(parts) > 1 {
+			source := parts[0]
+			isPath := strings.HasPrefix(source, "/") || strings.HasPrefix(source, ".") || strings.HasPrefix(source, "~")
+			if !isPath && !v.volumes[source] {
+				v.addf(item, itemField, "volume %q is not declared in the top-level volumes", source)
+			}
+		}
+	}
+}
+
+func (v *validator) serviceNetworks(n *yaml.Node, field string) {
+	var names []*yaml.Node
+
+	switch n.Kind {
+	case yaml.SequenceNode:
+		names = n.Content
+	case yaml.MappingNode:
+		for _, pair := range mappingPairs(n) {
+			names = append(names, pair[0])
+		}
+	default:
+		v.addf(n, field, "must be a list or mapping")
+		return
+	}
+
+	for _, name := range names {
+		if v.unresolved(name) {
+			continue
+		}
+		if !v.networks[name.Value] {
+			v.addf(name, field, "network %q is not declared in the top-level networks", name.Value)
+		}
+	}
+}
+
+func (v *validator) dependsOn(n *yaml.Node, field, service string) {
+	var names []*yaml.Node
+
+	switch n.Kind {
+	case yaml.SequenceNode:
+		names = n.Content
+	case yaml.MappingNode:
+		for _, pair := range mappingPairs(n) {
+			names = append(names, pair[0])
+		}
+	default:
+		v.addf(n, field, "must be a list or mapping")
+		return
+	}
+
+	for _, name := range names {
+		switch {
+		case v.unresolved(name):
+		case name.Value == service:
+			v.addf(name, field, "service cannot depend on itself")
+		case !v.services[name.Value]:
+			v.addf(name, field, "depends on undefined service %q", name.Value)
+		}
+	}
+}
+
+// mappingPairs returns the key/value pairs of a mapping, expanding YAML
+// merge keys (<<: *anchor). Explicit keys take precedence over merged ones.
+func mappingPairs(n *yaml.Node) [][2]*yaml.Node {
+	n = resolve(n)
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var pairs [][2]*yaml.Node
+	seen := make(map[string]bool)
+
+	for k := 0; k+1 < len(n.Content); k += 2 {
+		if n.Content[k].Value != "<<" {
+			pairs = append(pairs, [2]*yaml.Node{n.Content[k], n.Content[k+1]})
+			seen[n.Content[k].Value] = true
+		}
+	}
+
+	for k := 0; k+1 < len(n.Content); k += 2 {
+		if n.Content[k].Value != "<<" {
+			continue
+		}
+
+		sources := []*yaml.Node{n.Content[k+1]}
+		if merge := resolve(n.Content[k+1]); merge.Kind == yaml.SequenceNode {
+			sources = merge.Content
+		}
+		for _, source := range sources {
+			for _, pair := range mappingPairs(source) {
+				if !seen[pair[0].Value] {
+					pairs = append(pairs, pair)
+					seen[pair[0].Value] = true
+				}
+			}
+		}
+	}
+
+	return pairs
+}
+
+// resolve follows YAML aliases to the node they refer to
+func resolve(n *yaml.Node) *yaml.Node {
+	for n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	return n
+}
+
+func isNull(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.Tag == "!!null"
+}
@@ -0,0 +1,248 @@
+package compose
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckPortSpec(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr string
+	}{
+		{spec: "80"},
+		{spec: "8080:80"},
+		{spec: "127.0.0.1:8080:80"},
+		{spec: "127.0.0.1::80"},
+		{spec: "[::1]:8080:80"},
+		{spec: "53:53/udp"},
+		{spec: "9000-9010:9000-9010"},
+		{spec: "9000-9010"},
+		{spec: ":80"},
+		{spec: "80/sctp"},
+
+		{spec: "", wantErr: "invalid container port"},
+		{spec: "0", wantErr: "invalid container port"},
+		{spec: "65536", wantErr: "invalid container port"},
+		{spec: "http", wantErr: "invalid container port"},
+		{spec: "8080:", wantErr: "invalid container port"},
+		{spec: "99999:80", wantErr: "invalid host port"},
+		{spec: "9000-:80", wantErr: "invalid host port"},
+		{spec: "80/icmp", wantErr: "unsupported protocol"},
+		{spec: "[::1:8080:80", wantErr: "invalid host address"},
+		{spec: "1.2.3.4:1:2:3", wantErr: "invalid port mapping"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			err := checkPortSpec(tt.spec)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkPortSpec(%q) unexpected error: %v", tt.spec, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkPortSpec(%q) error = %v, want %q", tt.spec, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		env     map[string]string // interpolate with env first if set
+		want    []string          // expected problems, in order
+	}{
+		{
+			name: "valid",
+			content: `
+services:
+  web:
+    image: nginx
+    restart: on-failure:3
+    ports: ["8080:80", {target: 443, published: "8443"}]
+    volumes:
+      - data:/data
+      - ./conf:/etc/nginx:ro
+      - ~/cache:/cache
+      - /tmp
+      - {type: tmpfs, target: /run}
+    networks: [front]
+    depends_on: [db]
+  db:
+    image: postgres
+volumes:
+  data:
+networks:
+  front:
+`,
+		},
+		{
+			name: "structure",
+			content: `
+version: "3"
+service:
+  web: {}
+`,
+			want: []string{
+				`services: no services defined`,
+				`line 3: service: unknown top-level key`,
+			},
+		},
+		{
+			name: "service keys",
+			content: `
+services:
+  web:
+    imgae: nginx
+    networks: [front]
+    network_mode: host
+    restart: sometimes
+    depends_on: [web, db]
+    x-note: ignored
+`,
+			want: []string{
+				`line 4: services.web: unknown key "imgae"`,
+				`line 4: services.web: must specify an image or build`,
+				`line 4: services.web: networks and network_mode cannot be used together`,
+				`line 5: services.web.networks: network "front" is not declared in the top-level networks`,
+				`line 7: services.web.restart: unsupported restart policy "sometimes" (use no, always, on-failure or unless-stopped)`,
+				`line 8: services.web.depends_on: service cannot depend on itself`,
+				`line 8: services.web.depends_on: depends on undefined service "db"`,
+			},
+		},
+		{
+			name: "ports",
+			content: `
+services:
+  web:
+    image: nginx
+    ports:
+      - 80:http
+      - {published: 80}
+      - {target: 70000, proto: tcp}
+`,
+			want: []string{
+				`line 6: services.web.ports[0]: invalid container port in "80:http": "http" is not a valid port (1-65535)`,
+				`line 7: services.web.ports[1]: target is required`,
+				`line 8: services.web.ports[2].target: "70000" is not a valid port (1-65535)`,
+				`line 8: services.web.ports[2]: unknown key "proto"`,
+			},
+		},
+		{
+			name: "short volumes",
+			content: `
+services:
+  web:
+    image: nginx
+    volumes:
+      - data:/data
+      - ./conf:etc/nginx
+      - /a:/b:ro:extra
+      - relative
+`,
+			want: []string{
+				`line 6: services.web.volumes[0]: volume "data" is not declared in the top-level volumes`,
+				`line 7: services.web.volumes[1]: container path "etc/nginx" must be absolute`,
+				`line 8: services.web.volumes[2]: must be in format [SOURCE:]TARGET[:MODE]`,
+				`line 9: services.web.volumes[3]: container path "relative" must be absolute`,
+			},
+		},
+		{
+			name: "long volumes",
+			content: `
+services:
+  web:
+    image: nginx
+    volumes:
+      - {type: volume, source: data, target: /data}
+      - {type: disk, target: /disk}
+      - {source: ./conf, mode: ro}
+`,
+			want: []string{
+				`line 6: services.web.volumes[0]: volume "data" is not declared in the top-level volumes`,
+				`line 7: services.web.volumes[1]: type must be one of volume, bind, tmpfs, npipe, cluster or image`,
+				`line 8: services.web.volumes[2]: unknown key "mode"`,
+				`line 8: services.web.volumes[2]: type must be one of volume, bind, tmpfs, npipe, cluster or image`,
+				`line 8: services.web.volumes[2]: target is required`,
+			},
+		},
+		{
+			name: "variables are not checked before interpolation",
+			content: `
+services:
+  web:
+    image: nginx
+    restart: ${RESTART:-always}
+    ports: ["${PORT:?}:80", {target: "${TARGET}"}]
+    volumes: ["${DATA:-data}:/data", {type: "${TYPE}", source: "${SRC}", target: /x}]
+    networks: ["${NET}"]
+    depends_on: ["${DEP}"]
+`,
+		},
+		{
+			name: "variables are checked after interpolation",
+			env:  map[string]string{"PORT": "http", "DATA": "data", "NET": "back"},
+			content: `
+services:
+  web:
+    image: nginx
+    ports: ["${PORT}:80"]
+    volumes: ["${DATA}:/data"]
+    networks: ["${NET}"]
+`,
+			want: []string{
+				`line 5: services.web.ports[0]: invalid host port in "http:80": "http" is not a valid port (1-65535)`,
+				`line 6: services.web.volumes[0]: volume "data" is not declared in the top-level volumes`,
+				`line 7: services.web.networks: network "back" is not declared in the top-level networks`,
+			},
+		},
+		{
+			name: "anchors and merge keys",
+			content: `
+x-svc: &svc
+  image: nginx
+  ports: ["0:80"]
+services:
+  web:
+    <<: *svc
+  api: *svc
+`,
+			want: []string{
+				`line 4: services.web.ports[0]: invalid host port in "0:80": "0" is not a valid port (1-65535)`,
+				`line 4: services.api.ports[0]: invalid host port in "0:80": "0" is not a valid port (1-65535)`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := mustParse(t, tt.content)
+			if tt.env != nil {
+				if err := project.Interpolate(tt.env); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := project.Validate()
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("error = %v, want a *ValidationError", err)
+			}
+			if got := strings.Join(validationErr.Problems, "\n"); got != strings.Join(tt.want, "\n") {
+				t.Errorf("problems:\n%s\nwant:\n%s", got, strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
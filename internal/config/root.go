@@ -5,17 +5,58 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 )
 
 const (
 	ConfigDir  = ".remdoc"
 	ConfigFile = "config.json"
+
+	// DefaultContext is the name used for the first context and for
+	// configs migrated from the single-instance format
+	DefaultContext = "default"
+
+	// ContextEnv selects the active context, overriding current_context
+	ContextEnv = "REMDOC_CONTEXT"
+
+	// APIKeyEnv supplies a Portainer access token, overriding stored credentials
+	APIKeyEnv = "REMDOC_API_KEY"
+
+	// URLEnv supplies the Portainer URL for APIKeyEnv, so no context is needed
+	URLEnv = "REMDOC_URL"
+
+	// RegistryPasswordEnv supplies the password for --registry-username
+	RegistryPasswordEnv = "REMDOC_REGISTRY_PASSWORD"
+
+	// GitPasswordEnv supplies the password for --git-username
+	GitPasswordEnv = "REMDOC_GIT_PASSWORD"
 )
 
 // Config represents the CLI's persistent configuration
 type Config struct {
+	CurrentContext string              `json:"current_context"`
+	Contexts       map[string]*Context `json:"contexts"`
+}
+
+// Context holds the connection settings for a single Portainer instance
+type Context struct {
+	PortainerURL string `json:"portainer_url"`
+	JWT          string `json:"jwt,omitempty"`
+	Username     string `json:"username,omitempty"` // Used to re-authenticate when the JWT expires
+	APIKey       string `json:"api_key,omitempty"`  // Portainer access token, used instead of JWT
+	Endpoint     string `json:"endpoint,omitempty"` // Default endpoint ID or name
+}
+
+// HasCredentials reports whether the context holds a JWT or an API key
+func (c *Context) HasCredentials() bool {
+	return c.JWT != "" || c.APIKey != ""
+}
+
+// legacyConfig is the single-instance format used before contexts existed
+type legacyConfig struct {
 	PortainerURL string `json:"portainer_url"`
 	JWT          string `json:"jwt"`
+	Endpoint     string `json:"endpoint,omitempty"`
 }
 
 // ConfigPath returns the absolute path to the config file
@@ -27,7 +68,7 @@ func ConfigPath() (string, error) {
 	return filepath.Join(home, ConfigDir, ConfigFile), nil
 }
 
-// Load reads the config from disk
+// Load reads the config from disk, migrating the legacy format if needed
 func Load() (*Config, error) {
 	path, err := ConfigPath()
 	if err != nil {
@@ -47,9 +88,78 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("invalid config format: %w", err)
 	}
 
+	if cfg.Contexts == nil {
+		var legacy legacyConfig
+		if err := json.Unmarshal(data, &legacy); err != nil {
+			return nil, fmt.Errorf("invalid config format: %w", err)
+		}
+
+		cfg.Contexts = make(map[string]*Context)
+		if legacy.PortainerURL != "" {
+			cfg.Contexts[DefaultContext] = &Context{
+				PortainerURL: legacy.PortainerURL,
+				JWT:          legacy.JWT,
+				Endpoint:     legacy.Endpoint,
+			}
+			cfg.CurrentContext = DefaultContext
+
+			if err := Save(&cfg); err != nil {
+				return nil, fmt.Errorf("failed to migrate config: %w", err)
+			}
+		}
+	}
+
 	return &cfg, nil
 }
 
+// LoadOrNew reads the config from disk, returning an empty config if none exists yet
+func LoadOrNew() (*Config, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return &Config{Contexts: make(map[string]*Context)}, nil
+	}
+
+	return Load()
+}
+
+// Active returns the name and settings of the context to use. The override
+// (typically the --context flag) wins over REMDOC_CONTEXT, which wins over
+// the saved current context.
+func (c *Config) Active(override string) (string, *Context, error) {
+	name := override
+	if name == "" {
+		name = os.Getenv(ContextEnv)
+	}
+	if name == "" {
+		name = c.CurrentContext
+	}
+
+	if name == "" {
+		return "", nil, fmt.Errorf("no current context set (run 'remdoc login' or 'remdoc context use <name>')")
+	}
+
+	ctx, ok := c.Contexts[name]
+	if !ok {
+		return "", nil, fmt.Errorf("context %q not found (run 'remdoc context list' to see available contexts)", name)
+	}
+
+	return name, ctx, nil
+}
+
+// ContextNames returns all context names in sorted order
+func (c *Config) ContextNames() []string {
+	names := make([]string, 0, len(c.Contexts))
+	for name := range c.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // Save writes the config to disk
 func Save(cfg *Config) error {
 	path, err := ConfigPath()
@@ -74,4 +184,4 @@ func Save(cfg *Config) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}